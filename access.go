@@ -0,0 +1,157 @@
+package xdgdir
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheAccessIndex is name of sidecar index file in app's cache directory,
+// that records last access time of cache files with WithCacheAccessTracking option.
+const CacheAccessIndex = ".access"
+
+// ReadCacheFile reads app's cache file that has given name.
+// If the file does not exist, returns error that wraps ErrNotFound.
+//
+// If App is created with WithCacheAccessTracking option, the access is recorded for App#PruneCache.
+func (a App) ReadCacheFile(name string) ([]byte, error) {
+	fp, err := a.CacheFile(name)
+	if err != nil {
+		return nil, err
+	}
+	b, err := readFile(a.fs(), fp)
+	if err != nil {
+		return nil, wrapNotExist(err)
+	}
+	a.recordAccess(fp)
+	return b, nil
+}
+
+// PruneCache removes least recently used files from app's cache directory until total size of files
+// is at most maxBytes, and returns removed files.
+//
+// Last use of file is the later of its modification time and its last access that is recorded
+// by WithCacheAccessTracking option, so pruning is correct even on filesystems mounted with noatime.
+// Without the option, last use is last write. CacheAccessIndex is compacted to existing files after pruning.
+func (a App) PruneCache(maxBytes int64) ([]string, error) {
+	dir, err := a.cacheNamespaceDir()
+	if err != nil {
+		return nil, err
+	}
+	index := filepath.Join(dir, CacheAccessIndex)
+	mu := lockPath(index)
+	mu.Lock()
+	defer mu.Unlock()
+
+	accessed := a.readAccessIndex(index)
+	type entry struct {
+		path     string
+		rel      string
+		size     int64
+		lastUsed time.Time
+	}
+	var entries []entry
+	var total int64
+	err = a.walkFiles(dir, 0, func(fp string, _ int, d fs.DirEntry) error {
+		if fp == index {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, fp)
+		if err != nil {
+			return err
+		}
+		e := entry{fp, filepath.ToSlash(rel), fi.Size(), fi.ModTime()}
+		if t, ok := accessed[e.rel]; ok && t.After(e.lastUsed) {
+			e.lastUsed = t
+		}
+		entries = append(entries, e)
+		total += e.size
+		return nil
+	})
+	if err != nil {
+		return nil, ignoreNotExist(err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].lastUsed.Before(entries[j].lastUsed) })
+	var removed []string
+	for _, e := range entries {
+		if total <= maxBytes {
+			break
+		}
+		if err := a.fs().Remove(e.path); err != nil {
+			return removed, err
+		}
+		removed = append(removed, e.path)
+		total -= e.size
+		delete(accessed, e.rel)
+	}
+
+	if len(accessed) == 0 {
+		return removed, ignoreNotExist(a.fs().Remove(index))
+	}
+	var b bytes.Buffer
+	for _, e := range entries[len(removed):] {
+		if t, ok := accessed[e.rel]; ok {
+			fmt.Fprintf(&b, "%d\t%s\n", t.UnixNano(), e.rel)
+		}
+	}
+	return removed, a.writeFileAtomic(index, b.Bytes())
+}
+
+// recordAccess appends access of cache file at fp to CacheAccessIndex, if App is created with
+// WithCacheAccessTracking option. Failure is logged, because it must not fail reads and writes.
+func (a App) recordAccess(fp string) {
+	if !a.opt().cacheAccessTracking {
+		return
+	}
+	dir, err := a.cacheNamespaceDir()
+	if err != nil {
+		return
+	}
+	rel, err := filepath.Rel(dir, fp)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return
+	}
+	if err := a.appendAccess(filepath.Join(dir, CacheAccessIndex), filepath.ToSlash(rel)); err != nil {
+		a.logf("xdgdir: failed to record access of %s: %v", fp, err)
+	}
+}
+
+func (a App) appendAccess(index, rel string) error {
+	mu := lockPath(index)
+	mu.Lock()
+	defer mu.Unlock()
+	return appendFile(a.fs(), index, []byte(fmt.Sprintf("%d\t%s\n", time.Now().UnixNano(), rel)))
+}
+
+// readAccessIndex returns latest access time of each cache file in index. Malformed lines are ignored,
+// because the index is only hint and may have partially written line after crash.
+func (a App) readAccessIndex(index string) map[string]time.Time {
+	accessed := map[string]time.Time{}
+	b, err := readFile(a.fs(), index)
+	if err != nil {
+		return accessed
+	}
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		ts, rel, ok := strings.Cut(s.Text(), "\t")
+		n, err := strconv.ParseInt(ts, 10, 64)
+		if !ok || err != nil || rel == "" {
+			continue
+		}
+		if t := time.Unix(0, n); t.After(accessed[rel]) {
+			accessed[rel] = t
+		}
+	}
+	return accessed
+}
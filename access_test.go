@@ -0,0 +1,100 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppPruneCache(t *testing.T) {
+	table := []struct {
+		opts     []Option
+		expected string
+	}{
+		{nil, "a.bin"},
+		{[]Option{WithCacheAccessTracking()}, "b.bin"},
+	}
+	for _, tbl := range table {
+		dir := t.TempDir()
+		app := NewApp("test", append([]Option{WithEnviron([]string{"XDG_CACHE_HOME=" + dir})}, tbl.opts...)...)
+		now := time.Now()
+		for i, name := range []string{"a.bin", "b.bin", "c.bin"} {
+			fp, err := app.WriteCacheFile(name, []byte("0123456789"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			mtime := now.Add(time.Duration(i-3) * time.Hour)
+			if err := os.Chtimes(fp, mtime, mtime); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := app.ReadCacheFile("a.bin"); err != nil {
+			t.Fatal(err)
+		}
+
+		removed, err := app.PruneCache(20)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := []string{path(dir, "test", tbl.expected)}; !reflect.DeepEqual(removed, expected) {
+			t.Errorf("expected %v, but got %v", expected, removed)
+		}
+		if removed, err := app.PruneCache(20); err != nil || len(removed) != 0 {
+			t.Errorf("expected nothing removed, but got %v, %v", removed, err)
+		}
+	}
+
+	dir := t.TempDir()
+	app := NewApp("test", WithEnviron([]string{"XDG_CACHE_HOME=" + dir}), WithCacheAccessTracking())
+	if _, err := app.ReadCacheFile("missing.bin"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, but got %v", err)
+	}
+	if _, err := app.PruneCache(0); err != nil {
+		t.Errorf("missing cache dir should be ignored, but got %v", err)
+	}
+	for _, name := range []string{"x.bin", "y.bin"} {
+		if _, err := app.WriteCacheFile(name, []byte("0123456789")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := app.ReadCacheFile("y.bin"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.PruneCache(10); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(path(dir, "test", CacheAccessIndex))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(b)), "\n"); len(lines) != 1 || !strings.HasSuffix(lines[0], "\ty.bin") {
+		t.Errorf("index should be compacted to y.bin, but got %q", lines)
+	}
+}
+
+func TestAppCacheAccessTrackingFileSystem(t *testing.T) {
+	fsys := newMemFS()
+	dir := t.TempDir()
+	app := NewApp("test", WithEnviron([]string{"XDG_CACHE_HOME=" + dir}), WithCacheAccessTracking(), WithFileSystem(fsys))
+	if _, err := app.WriteCacheFile("a.bin", nil); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := app.ReadCacheFile("a.bin"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b, err := readFile(fsys, path(dir, "test", CacheAccessIndex))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(b)), "\n"); len(lines) != 3 || !strings.HasSuffix(lines[2], "\ta.bin") {
+		t.Errorf("expected write and 2 reads of a.bin, but got %q", lines)
+	}
+	if _, err := os.Stat(path(dir, "test", CacheAccessIndex)); !os.IsNotExist(err) {
+		t.Error("OS filesystem should not be touched")
+	}
+}
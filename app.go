@@ -2,20 +2,38 @@ package xdgdir
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 )
 
 // App is application name in XDG Base directories.
+//
+// Name must be non-empty and pass ValidateName, so zero value of App is invalid.
+// Methods that return error reject invalid name with ErrInvalidName,
+// and search methods do not search in system directories for it.
 type App struct {
 	// Name of app
 	Name string
+
+	// opts is shared by copies of App, so that App stays comparable and cheap to copy.
+	// It must not be modified after NewApp; derived apps are created by App#withOptions.
+	opts *options
 }
 
-// NewApp returns new app object that has given name.
-func NewApp(name string) App {
-	return App{Name: name}
+// NewApp returns new app object that has given name and is configured by given options.
+func NewApp(name string, opts ...Option) App {
+	a := App{Name: name, opts: &options{}}
+	for _, opt := range opts {
+		opt(&a)
+	}
+	if a.opt().nameTransform != nil {
+		a.opt().canonicalFrom, a.opts.canonicalName = name, a.opt().nameTransform(name)
+	}
+	return a
 }
 
 // ConfigDir returns base directory path of app's config files.
@@ -23,8 +41,11 @@ func NewApp(name string) App {
 // 1. If XDG_CONFIG_HOME envvar is defiend, returns $XDG_CONFIG_HOME/{{AppName}}.
 // 2. IF HOME envvar is defiend, returns $HOME/.config/{{AppName}}
 // 3. IF USERPROFILE envvar is defiend, returns $USERPROFILE/.config/{{AppName}} (for Windows)
+//
+// ".config" segment can be replaced by WithConfigFallback option.
+// If App is created with WithEnvPrefix option, {{PREFIX}}_CONFIG_HOME envvar precedes all of these.
 func (a App) ConfigDir() (string, error) {
-	return joinedPath(a.Name, ConfigDir)
+	return a.appDir("CONFIG_HOME", a.configHome)
 }
 
 // ConfigFile returns file path of app's config file that has given file name.
@@ -39,24 +60,36 @@ func (a App) ConfigFile(names ...string) (string, error) {
 // FindConfigFile finds config file that has given name.
 //
 // 1. Search in directory that is returned App#ConfigDir.
-// 2. Search in directories that are defiend at XDG_CONFIG_DIRS envvar.
+// 2. Search in directories that are defiend at XDG_CONFIG_DIRS envvar, or /etc/xdg if it is not defined.
 func (a App) FindConfigFile(names ...string) (string, error) {
-	d, _ := a.ConfigDir()
-	dirs := a.dirsForSearch(d, "XDG_CONFIG_DIRS")
-	f, err := findFile(dirs, names...)
+	f, _, err := a.FindConfigFileInfo(names...)
 	if err != nil {
 		return "", err
 	}
 	return f, nil
 }
 
+// FindConfigFileInfo is like FindConfigFile, but also returns file info that is obtained while searching,
+// so that caller does not need to stat the file again.
+func (a App) FindConfigFileInfo(names ...string) (string, fs.FileInfo, error) {
+	if err := a.validateName(); err != nil {
+		return "", nil, err
+	}
+	d, _ := a.ConfigDir()
+	dirs := a.dirsForSearch(d, "XDG_CONFIG_DIRS")
+	return a.findFileInfo(dirs, names...)
+}
+
 // DataDir returns base directory path of app's data files.
 //
 // 1. If XDG_data_HOME envvar is defiend, returns $XDG_DATA_HOME/{{AppName}}.
 // 2. IF HOME envvar is defiend, returns $HOME/.local/share/{{AppName}}
 // 3. IF USERPROFILE envvar is defiend, returns $USERPROFILE/.local/share/{{AppName}} (for Windows)
+//
+// ".local/share" segment can be replaced by WithDataFallback option.
+// If App is created with WithEnvPrefix option, {{PREFIX}}_DATA_HOME envvar precedes all of these.
 func (a App) DataDir() (string, error) {
-	return joinedPath(a.Name, DataDir)
+	return a.appDir("DATA_HOME", a.dataHome)
 }
 
 // DataFile returns file path of app's data file that has given file name.
@@ -71,24 +104,36 @@ func (a App) DataFile(names ...string) (string, error) {
 // FindDataFile finds data file that has given name.
 //
 // 1. Search in directory that is returned App#DataDir.
-// 2. Search in directories that are defiend at XDG_CONFIG_DIRS envvar.
+// 2. Search in directories that are defiend at XDG_DATA_DIRS envvar, or /usr/local/share and /usr/share if it is not defined.
 func (a App) FindDataFile(names ...string) (string, error) {
-	d, _ := a.DataDir()
-	dirs := a.dirsForSearch(d, "XDG_DATA_DIRS")
-	f, err := findFile(dirs, names...)
+	f, _, err := a.FindDataFileInfo(names...)
 	if err != nil {
 		return "", err
 	}
 	return f, nil
 }
 
+// FindDataFileInfo is like FindDataFile, but also returns file info that is obtained while searching,
+// so that caller does not need to stat the file again.
+func (a App) FindDataFileInfo(names ...string) (string, fs.FileInfo, error) {
+	if err := a.validateName(); err != nil {
+		return "", nil, err
+	}
+	d, _ := a.DataDir()
+	dirs := a.dirsForSearch(d, "XDG_DATA_DIRS")
+	return a.findFileInfo(dirs, names...)
+}
+
 // CacheDir returns base directory path of app's cache files.
 //
 // 1. If XDG_cache_HOME envvar is defiend, returns $XDG_CACHE_HOME/{{AppName}}.
 // 2. IF HOME envvar is defiend, returns $HOME/.cache/{{AppName}}
 // 3. IF USERPROFILE envvar is defiend, returns $USERPROFILE/.cache/{{AppName}} (for Windows)
+//
+// ".cache" segment can be replaced by WithCacheFallback option.
+// If App is created with WithEnvPrefix option, {{PREFIX}}_CACHE_HOME envvar precedes all of these.
 func (a App) CacheDir() (string, error) {
-	return joinedPath(a.Name, CacheDir)
+	return a.appDir("CACHE_HOME", a.cacheHome)
 }
 
 // CacheFile returns file path of app's cache file that has given file name.
@@ -96,16 +141,46 @@ func (a App) CacheDir() (string, error) {
 // 1. If XDG_cache_HOME envvar is defiend, returns $XDG_CACHE_HOME/{{AppName}}/{{names}}.
 // 2. IF HOME envvar is defiend, returns $HOME/.cache/{{AppName}}/{{names}}
 // 3. IF USERPROFILE envvar is defiend, returns $USERPROFILE/.cache/{{AppName}}/{{names}} (for Windows)
+//
+// If App is created with WithCacheNamespace option, the namespace is inserted before names.
 func (a App) CacheFile(names ...string) (string, error) {
-	return joinedPath(filepath.Join(names...), a.CacheDir)
+	return joinedPath(filepath.Join(names...), a.cacheNamespaceDir)
+}
+
+// StateDir returns base directory path of app's state files.
+//
+// 1. If XDG_STATE_HOME envvar is defined, returns $XDG_STATE_HOME/{{AppName}}.
+// 2. IF HOME envvar is defined, returns $HOME/.local/state/{{AppName}}
+// 3. IF USERPROFILE envvar is defined, returns $USERPROFILE/.local/state/{{AppName}} (for Windows)
+//
+// If App is created with WithEnvPrefix option, {{PREFIX}}_STATE_HOME envvar precedes all of these.
+func (a App) StateDir() (string, error) {
+	return a.appDir("STATE_HOME", a.stateHome)
+}
+
+// StateFile returns file path of app's state file that has given file name.
+//
+// 1. If XDG_STATE_HOME envvar is defined, returns $XDG_STATE_HOME/{{AppName}}/{{names}}.
+// 2. IF HOME envvar is defined, returns $HOME/.local/state/{{AppName}}/{{names}}
+// 3. IF USERPROFILE envvar is defined, returns $USERPROFILE/.local/state/{{AppName}}/{{names}} (for Windows)
+func (a App) StateFile(names ...string) (string, error) {
+	return joinedPath(filepath.Join(names...), a.StateDir)
 }
 
 // RuntimeDir returns base directory path of app's runtime.
 //
 // 1. If XDG_RUNTIME_DIR envvar is defiend, returns $XDG_RUNTIME_DIR/{{AppName}}.
 // 2. Returns temporary directory path that has subdirectory named AppName.
+//
+// If App is created with WithEnvPrefix option, {{PREFIX}}_RUNTIME_DIR envvar precedes all of these.
 func (a App) RuntimeDir() string {
-	return filepath.Join(RuntimeDir(), a.Name)
+	if a.opt().layout != nil && a.opt().layout.Runtime != "" {
+		return a.tenantPath(a.anchor(a.opt().layout.Runtime))
+	}
+	if dir := a.prefixedEnv("RUNTIME_DIR"); dir != "" {
+		return a.tenantPath(a.anchor(dir))
+	}
+	return a.tenantPath(filepath.Join(a.anchor(buildRuntime(a.getenv, a.uid())), a.CanonicalName()))
 }
 
 // RuntimeFile returns file path of app's runtime file that has given file name.
@@ -116,7 +191,153 @@ func (a App) RuntimeFile(names ...string) string {
 	return filepath.Join(a.RuntimeDir(), filepath.Join(names...))
 }
 
+// BinDir returns user's directory of executables that does not contain subdirectory for app,
+// because executables are found by PATH.
+//
+// 1. IF HOME envvar is defined, returns $HOME/.local/bin
+// 2. IF USERPROFILE envvar is defined, returns $USERPROFILE/.local/bin (for Windows)
+//
+// There is no envvar for this directory in XDG Base Directory Specification.
+func (a App) BinDir() (string, error) {
+	return a.anchorHome(binDir(a.getenv, a.goos()))
+}
+
+// appDir returns app's directory that is {{PREFIX}}_{{suffix}} envvar or AppName under home.
+func (a App) appDir(suffix string, home func() (string, error)) (string, error) {
+	if err := a.validateName(); err != nil {
+		return "", err
+	}
+	if a.opt().layout != nil {
+		dir := a.opt().layout.dir(suffix)
+		if dir == "" {
+			return "", ErrNoHome
+		}
+		return a.tenantPath(a.anchor(dir)), nil
+	}
+	if dir := a.prefixedEnv(suffix); dir != "" {
+		return a.tenantPath(a.anchor(dir)), nil
+	}
+	// Name is already validated, so joinedPath is not used here.
+	dir, err := home()
+	if err != nil {
+		return "", err
+	}
+	return a.tenantPath(filepath.Join(dir, a.CanonicalName())), nil
+}
+
+// zeroOptions is options of App that is not created by NewApp, such as zero value.
+var zeroOptions options
+
+// opt returns app's options for reading.
+func (a App) opt() *options {
+	if a.opts == nil {
+		return &zeroOptions
+	}
+	return a.opts
+}
+
+// withOptions returns copy of app whose options are modified by f, leaving options of app as is.
+func (a App) withOptions(f func(o *options)) App {
+	o := *a.opt()
+	f(&o)
+	a.opts = &o
+	return a
+}
+
+// CanonicalName returns directory name of app, that is App.Name transformed by WithNameTransform option.
+// Without the option, returns App.Name as is.
+//
+// The transformed name is computed once by NewApp, and computed again only if App.Name is changed after that.
+func (a App) CanonicalName() string {
+	if a.opt().nameTransform == nil {
+		return a.Name
+	}
+	if a.Name == a.opt().canonicalFrom {
+		return a.opt().canonicalName
+	}
+	return a.opt().nameTransform(a.Name)
+}
+
+// validateName checks that app's directory name is non-empty and valid, and that options are valid.
+// Empty name, ".", and names like "a/.." would put app's files directly into XDG base directories,
+// and names like ".." would put them outside of them.
+func (a App) validateName() error {
+	if a.opt().optionErr != nil {
+		return a.opt().optionErr
+	}
+	name := a.CanonicalName()
+	if name == "" {
+		return fmt.Errorf("%w: app name is empty", ErrInvalidName)
+	}
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	if p := filepath.FromSlash(name); name == "." || name == ".." || !filepath.IsLocal(p) || filepath.Clean(p) != p {
+		return fmt.Errorf("%w: app name %q is not clean relative path under base directory", ErrInvalidName, name)
+	}
+	if name == SharedCacheDir {
+		return fmt.Errorf("%w: app name %s is reserved for shared cache", ErrInvalidName, name)
+	}
+	if a.opt().nameTransform != nil && (name == "." || name == ".." || strings.ContainsAny(name, `/\`)) {
+		return fmt.Errorf("%w: app name %q is transformed to %q, that is not plain directory name", ErrInvalidName, a.Name, name)
+	}
+	if a.opt().tenant != nil {
+		return validateDirName("tenant id", *a.opt().tenant)
+	}
+	return nil
+}
+
+func (a App) prefixedEnv(suffix string) string {
+	if a.opt().envPrefix == "" {
+		return ""
+	}
+	return a.getenv(a.opt().envPrefix + "_" + suffix)
+}
+
+func (a App) configHome() (string, error) {
+	return a.anchorHome(buildHome(a.getenv, a.goos(), "XDG_CONFIG_HOME", fallback(a.opt().configFallback, ".config")))
+}
+
+func (a App) dataHome() (string, error) {
+	return a.anchorHome(buildHome(a.getenv, a.goos(), "XDG_DATA_HOME", fallback(a.opt().dataFallback, filepath.Join(".local", "share"))))
+}
+
+func (a App) cacheHome() (string, error) {
+	return a.anchorHome(buildHome(a.getenv, a.goos(), "XDG_CACHE_HOME", fallback(a.opt().cacheFallback, ".cache")))
+}
+
+func (a App) stateHome() (string, error) {
+	return a.anchorHome(buildHome(a.getenv, a.goos(), "XDG_STATE_HOME", ".local", "state"))
+}
+
+// anchor joins relative path p to working directory of WithWorkingDir option,
+// so that it does not depend on the process working directory.
+// Without the option, or if p is absolute or empty, returns p as is.
+func (a App) anchor(p string) string {
+	if a.opt().workingDir == "" || p == "" || filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(a.opt().workingDir, p)
+}
+
+func (a App) anchorHome(home string, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	return a.anchor(home), nil
+}
+
+func fallback(rel string, def string) string {
+	if rel == "" {
+		return def
+	}
+	return filepath.FromSlash(rel)
+}
+
 func joinedPath(name string, f func() (string, error)) (string, error) {
+	if err := ValidateName(name); err != nil {
+		return "", err
+	}
 	dir, err := f()
 	if err != nil {
 		return "", err
@@ -125,25 +346,94 @@ func joinedPath(name string, f func() (string, error)) (string, error) {
 	return filepath.Join(dir, name), nil
 }
 
+// dirsForSearch returns paths of search path. Directories that are rejected by WithRejectSymlinkedSearchDirs option
+// are replaced with empty string, so that indexes of other directories are same as layers of search path.
 func (a App) dirsForSearch(first string, env string) []string {
-	paths := []string{first}
-	for _, dir := range strings.Split(os.Getenv(env), string(os.PathListSeparator)) {
-		paths = append(paths, filepath.Join(dir, a.Name))
+	entries := a.searchPath(first, env)
+	paths := make([]string, len(entries))
+	for i, e := range entries {
+		paths[i] = e.Path
+		if a.opt().rejectSymlinkedSearchDirs && e.Path != "" && isSymlinked(a.fs(), e.Path) {
+			a.logf("xdgdir: search directory %s is ignored because it is symlink", e.Path)
+			paths[i] = ""
+		}
 	}
 	return paths
 }
 
-func findFile(dirs []string, names ...string) (string, error) {
+// isSymlinked reports whether dir or its parent, that is base directory like an entry of XDG_CONFIG_DIRS, is symlink.
+func isSymlinked(fsys FileSystem, dir string) bool {
+	for _, p := range []string{dir, filepath.Dir(dir)} {
+		if fi, err := lstat(fsys, p); err == nil && fi.Mode()&fs.ModeSymlink != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (a App) uid() string {
+	if a.opt().uid != "" {
+		return a.opt().uid
+	}
+	return strconv.Itoa(os.Getuid())
+}
+
+func (a App) goos() string {
+	if a.opt().goos != "" {
+		return a.opt().goos
+	}
+	return runtime.GOOS
+}
+
+func (a App) logf(format string, v ...interface{}) {
+	if a.opt().logf != nil {
+		a.opt().logf(format, v...)
+	}
+}
+
+func (a App) getenv(key string) string {
+	if a.opt().hostPaths {
+		if v, ok := a.hostEnv(key); ok {
+			return v
+		}
+	}
+	return a.rawGetenv(key)
+}
+
+func (a App) rawGetenv(key string) string {
+	if a.opt().env != nil {
+		return a.opt().env[key]
+	}
+	return os.Getenv(key)
+}
+
+func (a App) listSeparator() byte {
+	if a.opt().listSeparator != 0 {
+		return a.opt().listSeparator
+	}
+	return os.PathListSeparator
+}
+
+func (a App) findFile(dirs []string, names ...string) (string, error) {
+	fp, _, err := a.findFileInfo(dirs, names...)
+	return fp, err
+}
+
+func (a App) findFileInfo(dirs []string, names ...string) (string, fs.FileInfo, error) {
 	np := filepath.Join(names...)
+	if err := ValidateName(np); err != nil {
+		return "", nil, err
+	}
 	for _, dir := range dirs {
 		if dir == "" {
 			continue
 		}
 		fp := filepath.Join(dir, np)
-		if _, err := os.Stat(fp); err != nil {
+		fi, err := a.fs().Stat(fp)
+		if err != nil {
 			continue
 		}
-		return fp, nil
+		return fp, fi, nil
 	}
-	return "", fmt.Errorf("file %s is not found", np)
+	return "", nil, fmt.Errorf("file %s is %w", np, ErrNotFound)
 }
@@ -14,6 +14,27 @@ func TestNewApp(t *testing.T) {
 	}
 }
 
+func TestAppComparable(t *testing.T) {
+	os.Setenv("XDG_CONFIG_HOME", "a")
+	app := NewApp("test", WithEnviron([]string{"XDG_CONFIG_HOME=e"}), WithLogf(func(string, ...interface{}) {}))
+	tenant := app.Tenant("t1")
+	apps := map[App]bool{app: true, tenant: true, {}: true}
+	if len(apps) != 3 || !apps[app] || app == tenant {
+		t.Errorf("apps should be distinct map keys, but got %v", apps)
+	}
+
+	// Derived app does not modify options of its parent.
+	if dir, _ := app.ConfigDir(); dir != path("e", "test") {
+		t.Errorf("expected %s, but got %s", path("e", "test"), dir)
+	}
+	if dir, _ := tenant.ConfigDir(); dir != path("e", "test", "tenants", "t1") {
+		t.Errorf("expected %s, but got %s", path("e", "test", "tenants", "t1"), dir)
+	}
+	if dir, _ := (App{Name: "test"}).ConfigDir(); dir != path("a", "test") {
+		t.Errorf("expected %s, but got %s", path("a", "test"), dir)
+	}
+}
+
 func TestAppConfigDir(t *testing.T) {
 	app := NewApp("test")
 	table := []struct {
@@ -119,6 +140,41 @@ func TestAppCacheDir(t *testing.T) {
 	}
 }
 
+func TestAppStateDir(t *testing.T) {
+	app := NewApp("test")
+	table := []struct {
+		xdgHome     string
+		home        string
+		userProfile string
+		expected    string
+		err         bool
+	}{
+		{"a", "b", "c", path("a", "test"), false},
+		{"", "b", "c", path("b", ".local", "state", "test"), false},
+		{"", "", "c", path("c", ".local", "state", "test"), false},
+		{"", "", "", "", true},
+	}
+
+	for _, tbl := range table {
+		os.Setenv("XDG_STATE_HOME", tbl.xdgHome)
+		os.Setenv("HOME", tbl.home)
+		os.Setenv("USERPROFILE", tbl.userProfile)
+		dir, err := app.StateDir()
+		if tbl.err {
+			if err == nil {
+				t.Error("should raise error, but not raised")
+			}
+		} else {
+			if err != nil {
+				t.Error(err)
+			}
+			if dir != tbl.expected {
+				t.Errorf("expected %s, but got %s", tbl.expected, dir)
+			}
+		}
+	}
+}
+
 func TestAppRuntimeDir(t *testing.T) {
 	app := NewApp("test")
 	if app.RuntimeDir() == "" {
@@ -350,6 +406,42 @@ func TestAppCacheFileWithSubdirectory(t *testing.T) {
 	}
 }
 
+func TestAppStateFile(t *testing.T) {
+	app := NewApp("test")
+	name := "history"
+	table := []struct {
+		xdgHome     string
+		home        string
+		userProfile string
+		expected    string
+		err         bool
+	}{
+		{"a", "b", "c", path("a", "test", name), false},
+		{"", "b", "c", path("b", ".local", "state", "test", name), false},
+		{"", "", "c", path("c", ".local", "state", "test", name), false},
+		{"", "", "", "", true},
+	}
+
+	for _, tbl := range table {
+		os.Setenv("XDG_STATE_HOME", tbl.xdgHome)
+		os.Setenv("HOME", tbl.home)
+		os.Setenv("USERPROFILE", tbl.userProfile)
+		dir, err := app.StateFile(name)
+		if tbl.err {
+			if err == nil {
+				t.Error("should raise error, but not raised")
+			}
+		} else {
+			if err != nil {
+				t.Error(err)
+			}
+			if dir != tbl.expected {
+				t.Errorf("expected %s, but got %s", tbl.expected, dir)
+			}
+		}
+	}
+}
+
 func TestAppRuntimeFile(t *testing.T) {
 	app := NewApp("test")
 	name := "runtime.pid"
@@ -491,6 +583,44 @@ func TestAppFindDataFileWithSubdirectory(t *testing.T) {
 	}
 }
 
+func TestAppFindConfigFileInfo(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "a"))
+	os.Setenv("XDG_CONFIG_DIRS", join(path("testdata", "b"), path("testdata", "c")))
+
+	f, fi, err := app.FindConfigFileInfo("bbb.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := path("testdata", "b", "test", "bbb.txt"); f != expected {
+		t.Errorf("expected %s, but got %s", expected, f)
+	}
+	if fi.Name() != "bbb.txt" || fi.Size() == 0 {
+		t.Errorf("invalid file info %v", fi)
+	}
+
+	if _, fi, err := app.FindConfigFileInfo("zzz.txt"); err == nil || fi != nil {
+		t.Error("should raise error, but not raised")
+	}
+}
+
+func TestAppFindDataFileInfo(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_DATA_HOME", path("testdata", "a"))
+	os.Setenv("XDG_DATA_DIRS", join(path("testdata", "b"), path("testdata", "c")))
+
+	f, fi, err := app.FindDataFileInfo("d", "ddd.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := path("testdata", "c", "test", "d", "ddd.txt"); f != expected {
+		t.Errorf("expected %s, but got %s", expected, f)
+	}
+	if fi.Name() != "ddd.txt" {
+		t.Errorf("invalid file info %v", fi)
+	}
+}
+
 func openFile(path string) (string, error) {
 	f, err := os.Open(path)
 	if err != nil {
@@ -0,0 +1,49 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+)
+
+// AutostartFile returns file path of desktop entry that has given name in freedesktop autostart directory.
+//
+// Autostart directory is shared by all apps, so the path is not under app's config directory.
+//
+// 1. If XDG_CONFIG_HOME envvar is defined, returns $XDG_CONFIG_HOME/autostart/{{name}}.
+// 2. IF HOME envvar is defined, returns $HOME/.config/autostart/{{name}}
+// 3. IF USERPROFILE envvar is defined, returns $USERPROFILE/.config/autostart/{{name}} (for Windows)
+//
+// name must be plain file name, so that it cannot escape autostart directory. App's name is validated too.
+func (a App) AutostartFile(name string) (string, error) {
+	if err := a.validateName(); err != nil {
+		return "", err
+	}
+	if err := validateDirName("desktop entry name", name); err != nil {
+		return "", err
+	}
+	return joinedPath(filepath.Join("autostart", name), a.configHome)
+}
+
+// EnableAutostart writes given desktop entry to autostart directory as {{AppName}}.desktop.
+func (a App) EnableAutostart(desktopEntry []byte) error {
+	fp, err := a.AutostartFile(a.CanonicalName() + ".desktop")
+	if err != nil {
+		return err
+	}
+	return a.writeFileAtomic(fp, desktopEntry)
+}
+
+// DisableAutostart removes desktop entry that has given name from autostart directory.
+//
+// To undo EnableAutostart, pass {{AppName}}.desktop. If the entry does not exist, DisableAutostart does nothing.
+func (a App) DisableAutostart(name string) error {
+	fp, err := a.AutostartFile(name)
+	if err != nil {
+		return err
+	}
+	if err := a.fs().Remove(fp); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestAppAutostartFile(t *testing.T) {
+	app := NewApp("test")
+	table := []struct {
+		xdgHome  string
+		home     string
+		expected string
+		err      bool
+	}{
+		{"a", "b", path("a", "autostart", "test.desktop"), false},
+		{"", "b", path("b", ".config", "autostart", "test.desktop"), false},
+		{"", "", "", true},
+	}
+
+	os.Setenv("USERPROFILE", "")
+	for _, tbl := range table {
+		os.Setenv("XDG_CONFIG_HOME", tbl.xdgHome)
+		os.Setenv("HOME", tbl.home)
+		f, err := app.AutostartFile("test.desktop")
+		if tbl.err {
+			if err == nil {
+				t.Error("should raise error, but not raised")
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+		}
+		if f != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, f)
+		}
+	}
+}
+
+func TestAppEnableAutostart(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	entry := "[Desktop Entry]\nType=Application\nExec=test"
+
+	if err := app.EnableAutostart([]byte(entry)); err != nil {
+		t.Fatal(err)
+	}
+	fp, _ := app.AutostartFile("test.desktop")
+	if s, _ := openFile(fp); s != entry {
+		t.Errorf("expected %s, but got %s", entry, s)
+	}
+
+	if err := app.DisableAutostart("test.desktop"); err != nil {
+		t.Fatal(err)
+	}
+	if exists(fp) {
+		t.Errorf("%s should be removed", fp)
+	}
+	if err := app.DisableAutostart("test.desktop"); err != nil {
+		t.Errorf("disabling absent entry should be no-op, but got %v", err)
+	}
+}
+
+func TestAppAutostartFileInvalidName(t *testing.T) {
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	table := []struct {
+		app  string
+		name string
+	}{
+		{"test", ""},
+		{"test", ".."},
+		{"test", path("..", "evil.desktop")},
+		{"test", "a\x00.desktop"},
+		{"", "x.desktop"},
+	}
+	for _, tbl := range table {
+		if _, err := NewApp(tbl.app).AutostartFile(tbl.name); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("%q %q: expected ErrInvalidName, but got %v", tbl.app, tbl.name, err)
+		}
+	}
+	if err := NewApp("").EnableAutostart(nil); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName, but got %v", err)
+	}
+	if err := NewApp("test").DisableAutostart(".."); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName, but got %v", err)
+	}
+}
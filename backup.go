@@ -0,0 +1,46 @@
+package xdgdir
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// backup copies written file at path to backup directory of WithBackupDir option,
+// if path is in app's config or data directory.
+func (a App) backup(path string) error {
+	if a.opt().backupDir == "" {
+		return nil
+	}
+	err := a.copyToBackup(path)
+	if err == nil || a.opt().backupRequired {
+		return err
+	}
+	a.logf("xdgdir: %v", err)
+	return nil
+}
+
+func (a App) copyToBackup(path string) error {
+	for _, kind := range []Kind{Config, Data} {
+		dir, err := a.dir(kind)
+		if err != nil || !within(path, dir) || path == dir {
+			continue
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+		f, err := a.fs().Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+		defer f.Close()
+
+		// Backup itself is not backed up again, even if backup directory is under app's directory.
+		b := a.withOptions(func(o *options) { o.backupDir = "" })
+		if err := b.writeFileAtomicFrom(filepath.Join(a.anchor(a.opt().backupDir), kind.String(), rel), f); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestAppWithBackupDir(t *testing.T) {
+	home := t.TempDir()
+	backup := path(home, "backup")
+	env := []string{"HOME=" + home}
+	app := NewApp("test", WithEnviron(env), WithBackupDir(backup))
+
+	if err := app.WriteConfigFile("config.toml", []byte("config")); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.WriteDataFile("db/data.json", []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.WriteCacheFile("cache.bin", []byte("cache")); err != nil {
+		t.Fatal(err)
+	}
+	table := []struct {
+		path     string
+		expected string
+	}{
+		{path(backup, "config", "config.toml"), "config"},
+		{path(backup, "data", "db", "data.json"), "data"},
+	}
+	for _, tbl := range table {
+		b, err := os.ReadFile(tbl.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, b)
+		}
+	}
+	if _, err := os.Stat(path(backup, "cache")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("cache should not be backed up, but got %v", err)
+	}
+
+	// Backup fails because backup directory is file.
+	broken := path(home, "broken")
+	if err := os.WriteFile(broken, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	var logs []string
+	logf := func(format string, v ...interface{}) { logs = append(logs, format) }
+	app = NewApp("test", WithEnviron(env), WithBackupDir(broken), WithLogf(logf))
+	if err := app.WriteConfigFile("config.toml", []byte("updated")); err != nil {
+		t.Errorf("backup failure should not fail write, but got %v", err)
+	}
+	if len(logs) != 1 {
+		t.Errorf("backup failure should be logged, but got %v", logs)
+	}
+	app = NewApp("test", WithEnviron(env), WithBackupDir(broken), WithBackupRequired())
+	if err := app.WriteConfigFile("config.toml", []byte("required")); err == nil {
+		t.Error("should raise error for backup failure, but not raised")
+	}
+	if b, _ := os.ReadFile(path(home, ".config", "test", "config.toml")); string(b) != "required" {
+		t.Errorf("file should be written even if backup fails, but got %s", b)
+	}
+}
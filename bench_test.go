@@ -0,0 +1,30 @@
+package xdgdir
+
+import (
+	"os"
+	"testing"
+)
+
+func BenchmarkConfigDir(b *testing.B) {
+	os.Setenv("XDG_CONFIG_HOME", "")
+	os.Setenv("HOME", "/home/u")
+	app := NewApp("test")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := app.ConfigDir(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFindConfigFile(b *testing.B) {
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "z"))
+	os.Setenv("XDG_CONFIG_DIRS", join(path("testdata", "b"), path("testdata", "a")))
+	app := NewApp("test")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := app.FindConfigFile("aaa.txt"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
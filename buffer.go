@@ -0,0 +1,68 @@
+package xdgdir
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+)
+
+// ConfigBuffer is contents of app's config file that is loaded into memory to be edited and saved later,
+// for example by settings UI.
+type ConfigBuffer struct {
+	app   App
+	path  string
+	data  []byte
+	dirty bool
+}
+
+// LoadConfigBuffer reads app's config file that has given name into ConfigBuffer.
+//
+// The file is read from directory that is returned App#ConfigDir, not from XDG_CONFIG_DIRS, because it is saved there.
+// If the file does not exist, the buffer is empty and Save creates the file.
+func (a App) LoadConfigBuffer(name string) (*ConfigBuffer, error) {
+	fp, err := a.ConfigFile(name)
+	if err != nil {
+		return nil, err
+	}
+	b, err := readFile(a.fs(), fp)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return &ConfigBuffer{app: a, path: fp, data: b}, nil
+}
+
+// Path returns path of the config file.
+func (b *ConfigBuffer) Path() string {
+	return b.path
+}
+
+// Bytes returns contents of the buffer. Caller must not modify returned slice; use SetBytes instead.
+func (b *ConfigBuffer) Bytes() []byte {
+	return b.data
+}
+
+// SetBytes replaces contents of the buffer. The buffer becomes dirty if data differs from current contents.
+func (b *ConfigBuffer) SetBytes(data []byte) {
+	if bytes.Equal(b.data, data) {
+		return
+	}
+	b.data = append([]byte(nil), data...)
+	b.dirty = true
+}
+
+// Dirty reports whether the buffer is modified after it is loaded or saved.
+func (b *ConfigBuffer) Dirty() bool {
+	return b.dirty
+}
+
+// Save writes contents of the buffer to the config file atomically, if the buffer is dirty.
+func (b *ConfigBuffer) Save() error {
+	if !b.dirty {
+		return nil
+	}
+	if err := b.app.writeFileAtomic(b.path, b.data); err != nil {
+		return err
+	}
+	b.dirty = false
+	return nil
+}
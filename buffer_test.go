@@ -0,0 +1,48 @@
+package xdgdir
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAppLoadConfigBuffer(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	buf, err := app.LoadConfigBuffer("config.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buf.Bytes()) != 0 || buf.Dirty() {
+		t.Errorf("expected empty clean buffer, but got %q dirty=%v", buf.Bytes(), buf.Dirty())
+	}
+	if err := buf.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if exists(buf.Path()) {
+		t.Error("clean buffer should not be saved")
+	}
+
+	buf.SetBytes([]byte("a"))
+	if !buf.Dirty() {
+		t.Error("buffer should be dirty")
+	}
+	if err := buf.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Dirty() {
+		t.Error("buffer should be clean after save")
+	}
+
+	buf, err = app.LoadConfigBuffer("config.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(buf.Bytes()) != "a" {
+		t.Errorf("expected a, but got %s", buf.Bytes())
+	}
+	buf.SetBytes([]byte("a"))
+	if buf.Dirty() {
+		t.Error("setting same contents should not make buffer dirty")
+	}
+}
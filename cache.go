@@ -0,0 +1,160 @@
+package xdgdir
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WriteCacheFile writes data to app's cache file that has given name atomically, and returns path of written file.
+//
+// If App is created with WithCacheFallbackToTemp option and the write fails because disk is full or permission is denied,
+// the data is written under temporary directory instead and its path is returned.
+func (a App) WriteCacheFile(name string, data []byte) (string, error) {
+	fp, err := a.CacheFile(name)
+	if err == nil {
+		err = a.writeCache(fp, data)
+		if err == nil {
+			a.recordAccess(fp)
+			return fp, nil
+		}
+	}
+	if !a.opt().cacheFallbackToTemp || !isCacheFallbackError(err) {
+		return "", err
+	}
+
+	fp = filepath.Join(a.tempCacheDir(), name)
+	if err := a.writeCache(fp, data); err != nil {
+		return "", err
+	}
+	return fp, nil
+}
+
+// writeCache writes cache file atomically, or directly if App is created with WithFastCacheWrites option.
+func (a App) writeCache(path string, data []byte) error {
+	if a.opt().fastCacheWrites {
+		return a.writeFileDirect(path, data)
+	}
+	return a.writeFileAtomic(path, data)
+}
+
+// CacheFileForKey returns file path of app's cache file for given key, such as URL or query.
+//
+// File name is hex encoded SHA-256 digest of the key, so any key results in safe file name.
+// If App is created with WithCacheSharding option, the file is placed in two-character subdirectories
+// taken from the head of the digest, for example {{CacheDir}}/ab/cd/abcdef... for 2 levels.
+func (a App) CacheFileForKey(key string) (string, error) {
+	sum := sha256.Sum256([]byte(key))
+	digest := hex.EncodeToString(sum[:])
+
+	levels := a.opt().cacheSharding
+	if max := len(digest) / 2; levels > max {
+		levels = max
+	} else if levels < 0 {
+		levels = 0
+	}
+	names := make([]string, 0, levels+1)
+	for i := 0; i < levels; i++ {
+		names = append(names, digest[i*2:i*2+2])
+	}
+	return a.CacheFile(append(names, digest)...)
+}
+
+// TempCacheFile creates app's cache directory and new temporary file in it, and returns the opened file.
+//
+// The file name is generated from pattern like os.CreateTemp.
+// Since the file is in cache directory, it can be renamed to permanent cache file atomically,
+// which is not possible for files in $TMPDIR on other filesystem.
+// Caller is responsible for closing the file and for renaming or removing it.
+// This always uses the OS filesystem, even if App is created with WithFileSystem option.
+func (a App) TempCacheFile(pattern string) (*os.File, error) {
+	if err := a.osAccess(); err != nil {
+		return nil, err
+	}
+	dir, err := a.cacheNamespaceDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return os.CreateTemp(dir, pattern)
+}
+
+func (a App) tempCacheDir() string {
+	return filepath.Join(a.anchor(os.TempDir()), "xdgdir-cache-"+a.uid(), a.tenantPath(a.CanonicalName()), a.opt().cacheNamespace)
+}
+
+// cacheNamespaceDir returns app's cache directory that has namespace of WithCacheNamespace option.
+func (a App) cacheNamespaceDir() (string, error) {
+	dir, err := a.CacheDir()
+	if err != nil || a.opt().cacheNamespace == "" {
+		return dir, err
+	}
+	if err := validateDirName("cache namespace", a.opt().cacheNamespace); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, a.opt().cacheNamespace), nil
+}
+
+// PruneOtherCacheNamespaces removes subdirectories of app's cache directory other than the namespace
+// of WithCacheNamespace option, which are caches of other versions, and returns removed directories.
+//
+// Files directly in the cache directory are left. If App is created without WithCacheNamespace option, returns error.
+func (a App) PruneOtherCacheNamespaces() ([]string, error) {
+	if a.opt().cacheNamespace == "" {
+		return nil, errors.New("cache namespace is not set")
+	}
+	if err := validateDirName("cache namespace", a.opt().cacheNamespace); err != nil {
+		return nil, err
+	}
+	dir, err := a.CacheDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := a.osAccess(); err != nil {
+		return nil, err
+	}
+
+	fsys := a.fs()
+	entries, err := fsys.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var removed []string
+	for _, e := range entries {
+		if !e.IsDir() || e.Name() == a.opt().cacheNamespace {
+			continue
+		}
+		sub := filepath.Join(dir, e.Name())
+		if err := removeAll(fsys, sub); err != nil {
+			return removed, err
+		}
+		removed = append(removed, sub)
+	}
+	return removed, nil
+}
+
+// validateDirName checks that name is valid name of single directory, that does not escape its parent.
+// what is used in error message, like "cache namespace".
+func validateDirName(what, name string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("%w: %s %q must be plain directory name", ErrInvalidName, what, name)
+	}
+	return nil
+}
+
+func isCacheFallbackError(err error) bool {
+	return isDiskFull(err) || errors.Is(err, fs.ErrPermission)
+}
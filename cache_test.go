@@ -0,0 +1,225 @@
+package xdgdir
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppWriteCacheFile(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	fp, err := app.WriteCacheFile("cache.txt", []byte("cached"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := app.CacheFile("cache.txt")
+	if fp != expected {
+		t.Errorf("expected %s, but got %s", expected, fp)
+	}
+	if s, _ := openFile(fp); s != "cached" {
+		t.Errorf("expected cached, but got %s", s)
+	}
+}
+
+func TestAppWriteCacheFileWithFastCacheWrites(t *testing.T) {
+	app := NewApp("test", WithFastCacheWrites())
+	os.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	for _, s := range []string{"cached long", "cached"} {
+		fp, err := app.WriteCacheFile("cache.txt", []byte(s))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual, _ := openFile(fp); actual != s {
+			t.Errorf("expected %s, but got %s", s, actual)
+		}
+	}
+	dir, _ := app.CacheDir()
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected only cache.txt, but got %v", entries)
+	}
+}
+
+func TestAppWriteCacheFileWithFallbackToTemp(t *testing.T) {
+	if os.Getuid() == 0 {
+		t.Skip("permission is not denied for root")
+	}
+	home := t.TempDir()
+	if err := os.Chmod(home, 0500); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chmod(home, 0700)
+	os.Setenv("XDG_CACHE_HOME", home)
+
+	if _, err := NewApp("test").WriteCacheFile("cache.txt", []byte("cached")); err == nil {
+		t.Error("should raise error, but not raised")
+	}
+
+	app := NewApp("test", WithCacheFallbackToTemp())
+	defer os.RemoveAll(app.tempCacheDir())
+	fp, err := app.WriteCacheFile("cache.txt", []byte("cached"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := path(app.tempCacheDir(), "cache.txt"); fp != expected {
+		t.Errorf("expected %s, but got %s", expected, fp)
+	}
+	if s, _ := openFile(fp); s != "cached" {
+		t.Errorf("expected cached, but got %s", s)
+	}
+}
+
+func TestIsCacheFallbackError(t *testing.T) {
+	table := []struct {
+		err      error
+		expected bool
+	}{
+		{&os.PathError{Op: "open", Path: "x", Err: os.ErrPermission}, true},
+		{&os.PathError{Op: "open", Path: "x", Err: os.ErrNotExist}, false},
+		{nil, false},
+	}
+	for _, tbl := range table {
+		if actual := isCacheFallbackError(tbl.err); actual != tbl.expected {
+			t.Errorf("expected %v for %v, but got %v", tbl.expected, tbl.err, actual)
+		}
+	}
+}
+
+func TestAppCacheFileForKey(t *testing.T) {
+	os.Setenv("XDG_CACHE_HOME", "a")
+	digest := "4c3bdf38df787938557f0fb26aec18f1f59e2a06135c62294641fcf8b999d12a"
+	table := []struct {
+		levels   int
+		key      string
+		expected string
+	}{
+		{0, "https://example.com/?q=a/b", path("a", "test", digest)},
+		{2, "https://example.com/?q=a/b", path("a", "test", "4c", "3b", digest)},
+		{-1, "https://example.com/?q=a/b", path("a", "test", digest)},
+		{-5, "https://example.com/?q=a/b", path("a", "test", digest)},
+	}
+	for _, tbl := range table {
+		app := NewApp("test", WithCacheSharding(tbl.levels))
+		f, err := app.CacheFileForKey(tbl.key)
+		if err != nil {
+			t.Error(err)
+		}
+		if f != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, f)
+		}
+	}
+}
+
+func TestAppTempCacheFile(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	f, err := app.TempCacheFile("download-*.part")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString("downloaded"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	dir, _ := app.CacheDir()
+	if filepath.Dir(f.Name()) != dir {
+		t.Errorf("expected file in %s, but got %s", dir, f.Name())
+	}
+	if ok, _ := filepath.Match("download-*.part", filepath.Base(f.Name())); !ok {
+		t.Errorf("%s does not match pattern", f.Name())
+	}
+
+	dst, _ := app.CacheFile("downloaded.bin")
+	if err := os.Rename(f.Name(), dst); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := openFile(dst); s != "downloaded" {
+		t.Errorf("expected downloaded, but got %s", s)
+	}
+}
+
+func BenchmarkWriteCacheFile(b *testing.B) {
+	data := []byte("small cache entry")
+	for _, fast := range []bool{false, true} {
+		opts := []Option{}
+		if fast {
+			opts = append(opts, WithFastCacheWrites())
+		}
+		app := NewApp("test", opts...)
+		b.Run(fmt.Sprintf("fast=%v", fast), func(b *testing.B) {
+			os.Setenv("XDG_CACHE_HOME", b.TempDir())
+			for i := 0; i < b.N; i++ {
+				if _, err := app.WriteCacheFile(fmt.Sprintf("%d.txt", i%100), data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestAppWithCacheNamespace(t *testing.T) {
+	os.Setenv("XDG_CACHE_HOME", t.TempDir())
+	app := NewApp("test", WithCacheNamespace("v2"))
+	dir, _ := app.CacheDir()
+
+	fp, err := app.WriteCacheFile("cache.txt", []byte("cached"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := path(dir, "v2", "cache.txt"); fp != expected {
+		t.Errorf("expected %s, but got %s", expected, fp)
+	}
+	if _, err := NewApp("test", WithCacheNamespace("v1")).WriteCacheFile("cache.txt", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path(dir, "top.txt"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := app.PruneOtherCacheNamespaces()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 1 || removed[0] != path(dir, "v1") {
+		t.Errorf("expected %s to be removed, but got %v", path(dir, "v1"), removed)
+	}
+	for _, fp := range []string{path(dir, "v2", "cache.txt"), path(dir, "top.txt")} {
+		if !exists(fp) {
+			t.Errorf("%s should be left", fp)
+		}
+	}
+
+	for _, ns := range []string{"..", "a/b"} {
+		if _, err := NewApp("test", WithCacheNamespace(ns)).CacheFile("x"); err == nil {
+			t.Errorf("should raise error for %q, but not raised", ns)
+		}
+	}
+	if _, err := NewApp("test").PruneOtherCacheNamespaces(); err == nil {
+		t.Error("should raise error without namespace, but not raised")
+	}
+
+	// Directories are read and removed on the same FileSystem.
+	fsys := newMemFS()
+	memApp := NewApp("test", WithCacheNamespace("v2"), WithFileSystem(fsys))
+	if _, err := NewApp("test", WithCacheNamespace("v3"), WithFileSystem(fsys)).WriteCacheFile(path("sub", "cache.txt"), nil); err != nil {
+		t.Fatal(err)
+	}
+	removed, err = memApp.PruneOtherCacheNamespaces()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 1 || removed[0] != path(dir, "v3") {
+		t.Errorf("expected %s to be removed, but got %v", path(dir, "v3"), removed)
+	}
+	if _, err := fsys.Stat(path(dir, "v3", "sub", "cache.txt")); !os.IsNotExist(err) {
+		t.Error("cache file in other namespace should be removed")
+	}
+}
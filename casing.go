@@ -0,0 +1,43 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// CanonicalDirExists reports whether app's config directory exists, comparing its name case-insensitively,
+// and returns the name of the directory on disk.
+//
+// On case-insensitive filesystems, apps that are created as "MyApp" and "myapp" share one directory.
+// If existingName differs from AppName, app can warn about or reconcile the mismatch.
+// The parent directory is listed instead of stat, because stat does not report casing on disk.
+// If the parent directory does not exist, returns false without error.
+func (a App) CanonicalDirExists() (existingName string, ok bool, err error) {
+	dir, err := a.ConfigDir()
+	if err != nil {
+		return "", false, err
+	}
+	parent, base := filepath.Dir(dir), filepath.Base(dir)
+
+	entries, err := a.fs().ReadDir(parent)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	for _, e := range entries {
+		if e.IsDir() && e.Name() == base {
+			return base, true, nil
+		}
+	}
+	for _, e := range entries {
+		if e.IsDir() && strings.EqualFold(e.Name(), base) {
+			return e.Name(), true, nil
+		}
+	}
+	return "", false, nil
+}
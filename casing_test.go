@@ -0,0 +1,42 @@
+package xdgdir
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAppCanonicalDirExists(t *testing.T) {
+	home := t.TempDir()
+	os.Setenv("XDG_CONFIG_HOME", home)
+	if err := os.Mkdir(path(home, "MyApp"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path(home, "other"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	table := []struct {
+		name     string
+		expected string
+		ok       bool
+	}{
+		{"MyApp", "MyApp", true},
+		{"myapp", "MyApp", true},
+		{"OTHER", "", false},
+		{"none", "", false},
+	}
+	for _, tbl := range table {
+		name, ok, err := NewApp(tbl.name).CanonicalDirExists()
+		if err != nil {
+			t.Error(err)
+		}
+		if name != tbl.expected || ok != tbl.ok {
+			t.Errorf("expected %s %v for %s, but got %s %v", tbl.expected, tbl.ok, tbl.name, name, ok)
+		}
+	}
+
+	os.Setenv("XDG_CONFIG_HOME", path(home, "none"))
+	if _, ok, err := NewApp("test").CanonicalDirExists(); ok || err != nil {
+		t.Errorf("expected false without error, but got %v %v", ok, err)
+	}
+}
@@ -0,0 +1,79 @@
+package xdgdir
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ClearConfirm is confirmation token that must be passed to destructive Clear methods,
+// so that they are not called by accident.
+type ClearConfirm struct{}
+
+// ClearData removes all contents of app's data directory, but leaves the (empty) directory itself.
+//
+// If the directory does not exist, ClearData does nothing.
+// If the directory is symlink, ClearData refuses to clear it. Symlinks in the directory are removed without being followed.
+// It also refuses to clear if app's name is invalid, if the directory is XDG data home itself,
+// or if FileSystem of WithFileSystem option cannot tell symlinks because it does not support Lstat.
+func (a App) ClearData(confirm ClearConfirm) error {
+	return a.clearDir(a.DataDir, a.dataHome)
+}
+
+// ClearState removes all contents of app's state directory, but leaves the (empty) directory itself.
+//
+// If the directory does not exist, ClearState does nothing.
+// If the directory is symlink, ClearState refuses to clear it. Symlinks in the directory are removed without being followed.
+// It also refuses to clear if app's name is invalid, if the directory is XDG state home itself,
+// or if FileSystem of WithFileSystem option cannot tell symlinks because it does not support Lstat.
+func (a App) ClearState(confirm ClearConfirm) error {
+	return a.clearDir(a.StateDir, a.stateHome)
+}
+
+func (a App) clearDir(f, home func() (string, error)) error {
+	if err := a.validateName(); err != nil {
+		return err
+	}
+	dir, err := f()
+	if err != nil {
+		return err
+	}
+	// The base directory is shared by all apps, so it must never be cleared, even if envvars point app's directory at it.
+	if h, err := home(); err == nil && filepath.Clean(h) == filepath.Clean(dir) {
+		return fmt.Errorf("refuse to clear %s that is base directory shared by all apps", dir)
+	}
+	if err := a.osAccess(); err != nil {
+		return err
+	}
+
+	fsys := a.fs()
+	fi, err := lstatNoFollow(fsys, dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if errors.Is(err, errUnsupported) {
+		return fmt.Errorf("refuse to clear %s, because symlinks cannot be detected: %w", dir, err)
+	}
+	if err != nil {
+		return err
+	}
+	if fi.Mode()&os.ModeSymlink != 0 {
+		return fmt.Errorf("refuse to clear %s that is symlink", dir)
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("%s is not directory", dir)
+	}
+
+	entries, err := fsys.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := removeAll(fsys, filepath.Join(dir, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
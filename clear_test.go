@@ -0,0 +1,139 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestAppClearData(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_DATA_HOME", t.TempDir())
+	outside := t.TempDir()
+	if err := os.WriteFile(path(outside, "keep.txt"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	dir, _ := app.EnsureDataDir()
+	if err := os.MkdirAll(path(dir, "sub"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path(dir, "sub", "a.txt"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, path(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.ClearData(ClearConfirm{}); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected empty dir, but got %d entries", len(entries))
+	}
+	if _, err := os.Stat(path(outside, "keep.txt")); err != nil {
+		t.Error("file behind symlink should not be removed")
+	}
+}
+
+func TestAppClearStateAbsent(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	if err := app.ClearState(ClearConfirm{}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAppClearStateSymlink(t *testing.T) {
+	app := NewApp("test")
+	home := t.TempDir()
+	os.Setenv("XDG_STATE_HOME", home)
+	outside := t.TempDir()
+	if err := os.WriteFile(path(outside, "keep.txt"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, path(home, "test")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.ClearState(ClearConfirm{}); err == nil {
+		t.Error("should raise error, but not raised")
+	}
+	if _, err := os.Stat(path(outside, "keep.txt")); err != nil {
+		t.Error("file behind symlink should not be removed")
+	}
+}
+
+func TestAppClearDataFileSystem(t *testing.T) {
+	fsys := newMemFS()
+	app := NewApp("test", WithFileSystem(fsys))
+	home := t.TempDir()
+	os.Setenv("XDG_DATA_HOME", home)
+
+	if err := app.WriteDataFile(path("sub", "a.txt"), []byte("a")); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.ClearData(ClearConfirm{}); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := fsys.ReadDir(path(home, "test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected empty dir, but got %d entries", len(entries))
+	}
+	if _, err := os.Stat(path(home, "test")); !os.IsNotExist(err) {
+		t.Error("OS filesystem should not be touched")
+	}
+}
+
+// statOnlyFS is FileSystem that does not support optional methods such as Lstat.
+type statOnlyFS struct {
+	FileSystem
+}
+
+func TestAppClearDataRefused(t *testing.T) {
+	home := t.TempDir()
+	other := path(home, "other", "keep.txt")
+	if err := os.MkdirAll(path(home, "other"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(other, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	table := []struct {
+		name string
+		app  App
+	}{
+		{"dot", NewApp(".", WithEnviron([]string{"XDG_DATA_HOME=" + home}))},
+		{"parent", NewApp(path("a", ".."), WithEnviron([]string{"XDG_DATA_HOME=" + home}))},
+		{"base", NewApp("test", WithEnviron([]string{"XDG_DATA_HOME=" + home, "MYAPP_DATA_HOME=" + home}), WithEnvPrefix("MYAPP"))},
+	}
+	for _, tbl := range table {
+		if err := tbl.app.ClearData(ClearConfirm{}); err == nil {
+			t.Errorf("%s: should raise error, but not raised", tbl.name)
+		}
+		if !exists(other) {
+			t.Fatalf("%s: data of other app should not be removed", tbl.name)
+		}
+	}
+
+	fsys := newMemFS()
+	if err := NewApp("test", WithEnviron([]string{"XDG_DATA_HOME=" + home}), WithFileSystem(fsys)).WriteDataFile("a.txt", nil); err != nil {
+		t.Fatal(err)
+	}
+	app := NewApp("test", WithEnviron([]string{"XDG_DATA_HOME=" + home}), WithFileSystem(statOnlyFS{fsys}))
+	if err := app.ClearData(ClearConfirm{}); !errors.Is(err, errUnsupported) {
+		t.Errorf("expected unsupported error without Lstat, but got %v", err)
+	}
+	if _, err := fsys.Stat(path(home, "test", "a.txt")); err != nil {
+		t.Error("file should not be removed without Lstat")
+	}
+}
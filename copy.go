@@ -0,0 +1,157 @@
+package xdgdir
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+)
+
+// CopyOption configures CopyAppTree.
+type CopyOption func(*copyOptions)
+
+type copyOptions struct {
+	overwrite bool
+}
+
+// Overwrite makes CopyAppTree overwrite files that already exist in destination.
+func Overwrite() CopyOption {
+	return func(o *copyOptions) {
+		o.overwrite = true
+	}
+}
+
+// CopyAppTree copies src app's tree of given kind into dst app's tree of same kind, preserving structure.
+//
+// This is useful when app is renamed and wants to carry over user's files from the old name.
+// Directories are created as needed. Only regular files are copied; symlinks and other special files are skipped.
+// If src tree does not exist, CopyAppTree does nothing.
+// If some files already exist in dst tree, CopyAppTree returns error without copying anything,
+// unless Overwrite option is passed.
+// kind must be app's own tree, so Bin cannot be used.
+func CopyAppTree(src, dst App, kind Kind, opts ...CopyOption) error {
+	var o copyOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if kind == Bin {
+		return fmt.Errorf("cannot copy %v tree, which is not app's tree", kind)
+	}
+
+	srcDir, err := src.dir(kind)
+	if err != nil {
+		return err
+	}
+	dstDir, err := dst.dir(kind)
+	if err != nil {
+		return err
+	}
+
+	var files []string
+	if err := collectFiles(src.fs(), srcDir, "", &files); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if !o.overwrite {
+		for _, f := range files {
+			if _, err := dst.fs().Stat(filepath.Join(dstDir, f)); err == nil {
+				return fmt.Errorf("file %s %w", filepath.Join(dstDir, f), ErrAlreadyExists)
+			}
+		}
+	}
+
+	for _, f := range files {
+		if err := copyFile(src, filepath.Join(srcDir, f), dst, filepath.Join(dstDir, f)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectFiles appends paths of regular files under dir/rel, relative to dir, to files.
+func collectFiles(fsys FileSystem, dir, rel string, files *[]string) error {
+	// Entries that are read before error are collected, and then the error is returned.
+	entries, readErr := fsys.ReadDir(filepath.Join(dir, rel))
+	for _, e := range entries {
+		name := filepath.Join(rel, e.Name())
+		switch {
+		case e.IsDir():
+			if err := collectFiles(fsys, dir, name, files); err != nil {
+				return err
+			}
+		case e.Type().IsRegular():
+			*files = append(*files, name)
+		}
+	}
+	return readErr
+}
+
+func isCrossDevice(err error) bool {
+	return errCrossDevice != nil && errors.Is(err, errCrossDevice)
+}
+
+func copyFile(src App, srcPath string, dst App, dstPath string) error {
+	f, err := src.fs().Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return dst.writeFileAtomicFrom(dstPath, f)
+}
+
+// Promote moves app's file that has given name from tree of kind from to tree of kind to,
+// for lifecycle transitions like cache to data or runtime to state. Existing destination file is replaced.
+//
+// Destination directory is created as needed. The file is renamed if both trees are on same filesystem,
+// otherwise it is copied atomically and then removed from source.
+// Both kinds must be app's own trees, so Bin cannot be used.
+// name must be local path that does not leave the trees, so absolute path and ".." that escapes are rejected.
+func (a App) Promote(name string, from, to Kind) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	if !filepath.IsLocal(name) {
+		return fmt.Errorf("%w: %q must be local path in app's tree", ErrInvalidName, name)
+	}
+	if from == to {
+		return fmt.Errorf("cannot promote %s from %v to same tree", name, from)
+	}
+	for _, k := range []Kind{from, to} {
+		if k == Bin {
+			return fmt.Errorf("cannot promote %s with %v tree, which is not app's tree", name, k)
+		}
+	}
+	src, err := a.Resolve(from, name)
+	if err != nil {
+		return err
+	}
+	dst, err := a.Resolve(to, name)
+	if err != nil {
+		return err
+	}
+	fi, err := a.fs().Stat(src)
+	if err != nil {
+		return wrapNotExist(err)
+	}
+	if !fi.Mode().IsRegular() {
+		return fmt.Errorf("cannot promote %s, which is not regular file", src)
+	}
+
+	if err := a.fs().MkdirAll(filepath.Dir(dst), 0700); err != nil {
+		return err
+	}
+	err = a.fs().Rename(src, dst)
+	if err == nil {
+		return a.syncDir(filepath.Dir(dst))
+	}
+	// Rename fails across filesystems, such as tmpfs of runtime directory and disk of state directory.
+	if !isCrossDevice(err) {
+		return err
+	}
+	if err := copyFile(a, src, a, dst); err != nil {
+		return err
+	}
+	return a.fs().Remove(src)
+}
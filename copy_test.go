@@ -0,0 +1,159 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCopyAppTree(t *testing.T) {
+	os.Setenv("XDG_DATA_HOME", t.TempDir())
+	src := NewApp("old")
+	dst := NewApp("new")
+
+	if err := CopyAppTree(src, dst, Data); err != nil {
+		t.Errorf("absent src tree should be no-op, but got %v", err)
+	}
+
+	for _, name := range []string{"a.txt", path("sub", "b.txt")} {
+		if err := src.WriteDataFile(name, []byte(name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	srcDir, _ := src.DataDir()
+	if err := os.Symlink(path(srcDir, "a.txt"), path(srcDir, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CopyAppTree(src, dst, Data); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.txt", path("sub", "b.txt")} {
+		fp, _ := dst.DataFile(name)
+		if s, _ := openFile(fp); s != name {
+			t.Errorf("expected %s, but got %s", name, s)
+		}
+	}
+	if fp, _ := dst.DataFile("link"); exists(fp) {
+		t.Error("symlink should be skipped")
+	}
+
+	if err := CopyAppTree(src, dst, Bin); err == nil {
+		t.Error("Bin tree should be rejected, but not rejected")
+	}
+}
+
+func TestCopyAppTreeOverwrite(t *testing.T) {
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	src := NewApp("old")
+	dst := NewApp("new")
+	src.WriteConfigFile("a.txt", []byte("src a"))
+	src.WriteConfigFile("b.txt", []byte("src b"))
+	dst.WriteConfigFile("b.txt", []byte("dst b"))
+
+	if err := CopyAppTree(src, dst, Config); err == nil {
+		t.Error("should raise error, but not raised")
+	}
+	if fp, _ := dst.ConfigFile("a.txt"); exists(fp) {
+		t.Error("nothing should be copied when conflict exists")
+	}
+
+	if err := CopyAppTree(src, dst, Config, Overwrite()); err != nil {
+		t.Fatal(err)
+	}
+	fp, _ := dst.ConfigFile("b.txt")
+	if s, _ := openFile(fp); s != "src b" {
+		t.Errorf("expected src b, but got %s", s)
+	}
+}
+
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// crossDeviceFS is FileSystem that cannot rename files across directories, like renames across filesystems.
+type crossDeviceFS struct {
+	osFileSystem
+}
+
+func (c crossDeviceFS) Rename(oldpath, newpath string) error {
+	if filepath.Dir(oldpath) != filepath.Dir(newpath) {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: errCrossDevice}
+	}
+	return c.osFileSystem.Rename(oldpath, newpath)
+}
+
+// deniedRenameFS is FileSystem that cannot rename files.
+type deniedRenameFS struct {
+	osFileSystem
+}
+
+func (deniedRenameFS) Rename(oldpath, newpath string) error {
+	return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: fs.ErrPermission}
+}
+
+func TestAppPromote(t *testing.T) {
+	if errCrossDevice == nil {
+		t.Skip("cross-device rename cannot be detected")
+	}
+	dir := t.TempDir()
+	env := []string{"XDG_CACHE_HOME=" + path(dir, "cache"), "XDG_DATA_HOME=" + path(dir, "data"), "XDG_STATE_HOME=" + path(dir, "state"), "XDG_RUNTIME_DIR=" + path(dir, "run")}
+
+	table := []struct {
+		from, to Kind
+		opts     []Option
+	}{
+		{Cache, Data, nil},
+		{Runtime, State, []Option{WithFileSystem(crossDeviceFS{})}},
+	}
+	for _, tbl := range table {
+		app := NewApp("test", append([]Option{WithEnviron(env)}, tbl.opts...)...)
+		src, _ := app.Resolve(tbl.from, path("sub", "artifact.bin"))
+		dst, _ := app.Resolve(tbl.to, path("sub", "artifact.bin"))
+		os.MkdirAll(filepath.Dir(src), 0700)
+		if err := os.WriteFile(src, []byte("artifact"), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := app.Promote(path("sub", "artifact.bin"), tbl.from, tbl.to); err != nil {
+			t.Fatal(err)
+		}
+		if b, err := os.ReadFile(dst); err != nil || string(b) != "artifact" {
+			t.Errorf("%v to %v: expected artifact, but got %s, %v", tbl.from, tbl.to, b, err)
+		}
+		if _, err := os.Stat(src); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%v to %v: source should be removed, but got %v", tbl.from, tbl.to, err)
+		}
+		if err := app.Promote(path("sub", "artifact.bin"), tbl.from, tbl.to); !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, but got %v", err)
+		}
+	}
+
+	app := NewApp("test", WithEnviron(env))
+	for _, kinds := range [][2]Kind{{Cache, Cache}, {Cache, Bin}, {Bin, Data}} {
+		if err := app.Promote("artifact.bin", kinds[0], kinds[1]); err == nil {
+			t.Errorf("%v to %v should be rejected, but not rejected", kinds[0], kinds[1])
+		}
+	}
+	for _, name := range []string{"a\x00", path("..", "..", "x"), path("sub", "..", "..", "x"), path(dir, "x"), ""} {
+		if err := app.Promote(name, Cache, Data); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("%q: expected ErrInvalidName, but got %v", name, err)
+		}
+	}
+
+	// Rename errors other than cross-device are not hidden by copy.
+	app = NewApp("test", WithEnviron(env), WithFileSystem(deniedRenameFS{}))
+	src, _ := app.CacheFile("denied.bin")
+	if err := os.WriteFile(src, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Promote("denied.bin", Cache, Data); !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("expected permission error, but got %v", err)
+	}
+	if !exists(src) {
+		t.Error("source should be kept")
+	}
+}
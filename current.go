@@ -0,0 +1,81 @@
+package xdgdir
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SetCurrentData points app's data file linkName at app's data file target.
+//
+// Symlink is created under temporary name and renamed over linkName,
+// so readers never see a broken or missing link.
+// On Windows, if symlinks are not permitted, small pointer file that contains target path is written instead.
+// So is on FileSystem of WithFileSystem option that does not support symlinks.
+func (a App) SetCurrentData(target, linkName string) error {
+	tp, err := a.DataFile(target)
+	if err != nil {
+		return err
+	}
+	lp, err := a.DataFile(linkName)
+	if err != nil {
+		return err
+	}
+
+	if err := a.osAccess(); err != nil {
+		return err
+	}
+
+	mu := lockPath(lp)
+	mu.Lock()
+	defer mu.Unlock()
+
+	fsys := a.fs()
+	dir := filepath.Dir(lp)
+	if err := fsys.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	tmp := filepath.Join(dir, fmt.Sprintf(".%s.%d.tmp", filepath.Base(lp), os.Getpid()))
+	fsys.Remove(tmp)
+	if err := symlink(fsys, tp, tmp); err != nil {
+		if a.goos() != "windows" && !errors.Is(err, errUnsupported) {
+			return err
+		}
+		return a.writeFileAtomic(lp, []byte(tp))
+	}
+	if err := fsys.Rename(tmp, lp); err != nil {
+		fsys.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// ReadCurrentData returns path that app's data file linkName points at.
+//
+// 1. If linkName is symlink, returns its target.
+// 2. Otherwise, returns path written in pointer file (for Windows).
+func (a App) ReadCurrentData(linkName string) (string, error) {
+	lp, err := a.DataFile(linkName)
+	if err != nil {
+		return "", err
+	}
+
+	if err := a.osAccess(); err != nil {
+		return "", err
+	}
+	fsys := a.fs()
+	fi, err := lstat(fsys, lp)
+	if err != nil {
+		return "", wrapNotExist(err)
+	}
+	if fi.Mode()&os.ModeSymlink != 0 {
+		return readlink(fsys, lp)
+	}
+	b, err := readFile(fsys, lp)
+	if err != nil {
+		return "", wrapNotExist(err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
@@ -0,0 +1,99 @@
+package xdgdir
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+)
+
+func TestAppSetCurrentData(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	for _, v := range []string{"v1", "v2"} {
+		dir, _ := app.DataFile("versions", v)
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path(dir, "ver.txt"), []byte(v), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := app.SetCurrentData(path("versions", v), "current"); err != nil {
+			t.Fatal(err)
+		}
+		cur, err := app.ReadCurrentData("current")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cur != dir {
+			t.Errorf("expected %s, but got %s", dir, cur)
+		}
+		fp, _ := app.DataFile("current", "ver.txt")
+		if s, _ := openFile(fp); s != v {
+			t.Errorf("expected %s, but got %s", v, s)
+		}
+	}
+}
+
+func TestAppReadCurrentDataWithoutLink(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	if _, err := app.ReadCurrentData("current"); err == nil {
+		t.Error("should raise error, but not raised")
+	}
+}
+
+func TestAppSetCurrentDataWindowsFallback(t *testing.T) {
+	app := NewApp("test", WithGOOS("windows"))
+	home := t.TempDir()
+	os.Setenv("XDG_DATA_HOME", home)
+	dir, _ := app.DataDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	// existing directory makes symlink creation fail like Windows without symlink privilege
+	tmp := path(dir, ".current."+strconv.Itoa(os.Getpid())+".tmp")
+	if err := os.MkdirAll(path(tmp, "x"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.SetCurrentData("v1", "current"); err != nil {
+		t.Fatal(err)
+	}
+	cur, err := app.ReadCurrentData("current")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := path(dir, "v1"); cur != expected {
+		t.Errorf("expected %s, but got %s", expected, cur)
+	}
+
+	if err := NewApp("test", WithGOOS("linux")).SetCurrentData("v1", "current"); err == nil {
+		t.Error("should raise error without fallback, but not raised")
+	}
+}
+
+func TestAppSetCurrentDataFileSystem(t *testing.T) {
+	fsys := newMemFS()
+	app := NewApp("test", WithFileSystem(fsys))
+	home := t.TempDir()
+	os.Setenv("XDG_DATA_HOME", home)
+
+	// memFS does not support symlinks, so pointer file is written.
+	if err := app.SetCurrentData("v1", "current"); err != nil {
+		t.Fatal(err)
+	}
+	cur, err := app.ReadCurrentData("current")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := path(home, "test", "v1"); cur != expected {
+		t.Errorf("expected %s, but got %s", expected, cur)
+	}
+	if _, err := os.Lstat(path(home, "test", "current")); !os.IsNotExist(err) {
+		t.Error("OS filesystem should not be touched")
+	}
+}
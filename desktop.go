@@ -0,0 +1,42 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+)
+
+// DesktopEntryFile returns file path of app's desktop entry in freedesktop applications directory.
+//
+// Applications directory is shared by all apps, so the path is not under app's data directory.
+//
+// 1. If XDG_DATA_HOME envvar is defined, returns $XDG_DATA_HOME/applications/{{AppName}}.desktop.
+// 2. IF HOME envvar is defined, returns $HOME/.local/share/applications/{{AppName}}.desktop
+// 3. IF USERPROFILE envvar is defined, returns $USERPROFILE/.local/share/applications/{{AppName}}.desktop (for Windows)
+func (a App) DesktopEntryFile() (string, error) {
+	if err := a.validateName(); err != nil {
+		return "", err
+	}
+	return joinedPath(filepath.Join("applications", a.CanonicalName()+".desktop"), a.dataHome)
+}
+
+// InstallDesktopEntry writes given desktop entry to DesktopEntryFile atomically.
+func (a App) InstallDesktopEntry(content []byte) error {
+	fp, err := a.DesktopEntryFile()
+	if err != nil {
+		return err
+	}
+	return a.writeFileAtomic(fp, content)
+}
+
+// UninstallDesktopEntry removes DesktopEntryFile. If the entry does not exist, UninstallDesktopEntry does nothing.
+func (a App) UninstallDesktopEntry() error {
+	fp, err := a.DesktopEntryFile()
+	if err != nil {
+		return err
+	}
+	if err := a.fs().Remove(fp); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
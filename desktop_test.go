@@ -0,0 +1,64 @@
+package xdgdir
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAppDesktopEntryFile(t *testing.T) {
+	table := []struct {
+		name     string
+		xdgHome  string
+		home     string
+		expected string
+		err      bool
+	}{
+		{"test", "a", "b", path("a", "applications", "test.desktop"), false},
+		{"test", "", "b", path("b", ".local", "share", "applications", "test.desktop"), false},
+		{"test", "", "", "", true},
+		{"", "a", "b", "", true},
+	}
+
+	os.Setenv("USERPROFILE", "")
+	for _, tbl := range table {
+		os.Setenv("XDG_DATA_HOME", tbl.xdgHome)
+		os.Setenv("HOME", tbl.home)
+		f, err := NewApp(tbl.name).DesktopEntryFile()
+		if tbl.err {
+			if err == nil {
+				t.Error("should raise error, but not raised")
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+		}
+		if f != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, f)
+		}
+	}
+}
+
+func TestAppInstallDesktopEntry(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_DATA_HOME", t.TempDir())
+	entry := "[Desktop Entry]\nType=Application\nExec=test"
+
+	if err := app.InstallDesktopEntry([]byte(entry)); err != nil {
+		t.Fatal(err)
+	}
+	fp, _ := app.DesktopEntryFile()
+	if s, _ := openFile(fp); s != entry {
+		t.Errorf("expected %s, but got %s", entry, s)
+	}
+
+	if err := app.UninstallDesktopEntry(); err != nil {
+		t.Fatal(err)
+	}
+	if exists(fp) {
+		t.Errorf("%s should be removed", fp)
+	}
+	if err := app.UninstallDesktopEntry(); err != nil {
+		t.Errorf("uninstalling absent entry should be no-op, but got %v", err)
+	}
+}
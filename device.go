@@ -0,0 +1,26 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package xdgdir
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// errCrossDevice is error of rename across filesystems.
+var errCrossDevice error = syscall.EXDEV
+
+// sameDevice reports whether files of a and b are on same filesystem.
+// If it cannot be determined, for example because FileSystem is not the OS filesystem, it reports true.
+func sameDevice(a, b fs.FileInfo) bool {
+	sa, ok := a.Sys().(*syscall.Stat_t)
+	if !ok {
+		return true
+	}
+	sb, ok := b.Sys().(*syscall.Stat_t)
+	if !ok {
+		return true
+	}
+	return sa.Dev == sb.Dev
+}
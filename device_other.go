@@ -0,0 +1,17 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows && !plan9
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!windows,!plan9
+
+package xdgdir
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// errCrossDevice is error of rename across filesystems.
+var errCrossDevice error = syscall.EXDEV
+
+// sameDevice always reports true, because this platform does not expose device of files.
+func sameDevice(a, b fs.FileInfo) bool {
+	return true
+}
@@ -0,0 +1,11 @@
+package xdgdir
+
+import "io/fs"
+
+// errCrossDevice is nil, because Plan 9 errors are strings, and rename across directories is not supported anyway.
+var errCrossDevice error
+
+// sameDevice always reports true, because this platform does not expose device of files.
+func sameDevice(a, b fs.FileInfo) bool {
+	return true
+}
@@ -0,0 +1,17 @@
+package xdgdir
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// errorNotSameDevice is ERROR_NOT_SAME_DEVICE.
+const errorNotSameDevice syscall.Errno = 17
+
+// errCrossDevice is error of rename across volumes.
+var errCrossDevice error = errorNotSameDevice
+
+// sameDevice always reports true, because device of files is not exposed by fs.FileInfo on Windows.
+func sameDevice(a, b fs.FileInfo) bool {
+	return true
+}
@@ -0,0 +1,113 @@
+package xdgdir
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+)
+
+// DiffKind is kind of change of DiffEntry.
+type DiffKind int
+
+const (
+	// DiffAdded is file that exists only in config directory.
+	DiffAdded DiffKind = iota
+	// DiffModified is file that exists in both, but has different content.
+	DiffModified
+	// DiffRemoved is file that exists only in baseline.
+	DiffRemoved
+)
+
+// String returns "added", "modified" or "removed".
+func (k DiffKind) String() string {
+	switch k {
+	case DiffAdded:
+		return "added"
+	case DiffModified:
+		return "modified"
+	case DiffRemoved:
+		return "removed"
+	}
+	return fmt.Sprintf("DiffKind(%d)", int(k))
+}
+
+// DiffEntry is file that differs between config directory and baseline.
+type DiffEntry struct {
+	// Path is slash separated path relative to config directory and root of baseline.
+	Path string
+	Kind DiffKind
+}
+
+// DiffConfigAgainst compares files in App#ConfigDir with baseline src, for example embedded default config,
+// and returns changed files sorted by path. Files that have same content are not returned.
+//
+// Contents are compared by hash, so files are streamed rather than loaded into memory.
+// Only regular files are compared, and missing config directory is treated as empty.
+func (a App) DiffConfigAgainst(src fs.FS) ([]DiffEntry, error) {
+	dir, err := a.ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	base := map[string][]byte{}
+	err = fs.WalkDir(src, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		sum, err := hashFile(src.Open, p)
+		base[p] = sum
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []DiffEntry
+	err = a.walkFiles(dir, 0, func(fp string, _ int, _ fs.DirEntry) error {
+		rel, err := filepath.Rel(dir, fp)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		want, ok := base[rel]
+		if !ok {
+			diffs = append(diffs, DiffEntry{Path: rel, Kind: DiffAdded})
+			return nil
+		}
+		delete(base, rel)
+		sum, err := hashFile(a.fs().Open, fp)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(sum, want) {
+			diffs = append(diffs, DiffEntry{Path: rel, Kind: DiffModified})
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	for p := range base {
+		diffs = append(diffs, DiffEntry{Path: p, Kind: DiffRemoved})
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+	return diffs, nil
+}
+
+func hashFile(open func(string) (fs.File, error), name string) ([]byte, error) {
+	f, err := open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,63 @@
+package xdgdir
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestAppDiffConfigAgainst(t *testing.T) {
+	dir := t.TempDir()
+	app := NewApp("test", WithEnviron([]string{"XDG_CONFIG_HOME=" + dir}))
+	defaults := fstest.MapFS{
+		"same.toml":          {Data: []byte("a = 1\n")},
+		"changed.toml":       {Data: []byte("b = 1\n")},
+		"removed.toml":       {Data: []byte("c = 1\n")},
+		"themes/dark.toml":   {Data: []byte("dark\n")},
+		"themes/bright.toml": {Data: []byte("bright\n")},
+	}
+
+	diffs, err := app.DiffConfigAgainst(defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != len(defaults) {
+		t.Errorf("all files should be removed for missing config dir, but got %v", diffs)
+	}
+
+	files := map[string]string{
+		"same.toml":        "a = 1\n",
+		"changed.toml":     "b = 2\n",
+		"added.toml":       "d = 1\n",
+		"themes/dark.toml": "dark\n",
+		"themes/mine.toml": "mine\n",
+	}
+	for name, content := range files {
+		fp := path(dir, "test", name)
+		if err := os.MkdirAll(path(fp, ".."), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fp, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	diffs, err = app.DiffConfigAgainst(defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []DiffEntry{
+		{"added.toml", DiffAdded},
+		{"changed.toml", DiffModified},
+		{"removed.toml", DiffRemoved},
+		{"themes/bright.toml", DiffRemoved},
+		{"themes/mine.toml", DiffAdded},
+	}
+	if !reflect.DeepEqual(diffs, expected) {
+		t.Errorf("expected %v, but got %v", expected, diffs)
+	}
+	if s := DiffModified.String(); s != "modified" {
+		t.Errorf("expected modified, but got %s", s)
+	}
+}
@@ -0,0 +1,6 @@
+package xdgdir
+
+// isDiskFull always reports false, because Plan 9 has no errno for full disk.
+func isDiskFull(err error) bool {
+	return false
+}
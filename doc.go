@@ -0,0 +1,16 @@
+// Package xdgdir resolves directories and files based on XDG Base Directory Specification.
+//
+// Methods that return paths, such as App#ConfigDir and App#ConfigFile, are pure: they never touch disk.
+// Find and read methods, such as App#FindConfigFile, only read disk.
+// Directories are created by Ensure methods (App#EnsureConfigDir etc.)
+// and by write methods (App#EditConfigFile, App#WriteCacheFile etc.) that create parent directories of written file.
+// With WithLazyCreate option, Ensure methods do not create directories either,
+// so directories are created only when a file is actually written.
+// With WithNoFilesystemAccess option, App never touches disk, and methods that need disk fail.
+//
+// Home directory is taken from HOME envvar, and then from USERPROFILE envvar (for Windows) or $home (for Plan 9).
+// On platforms without these envvars, such as js/wasm, methods that need home directory return ErrNoHome
+// unless XDG envvars are defined.
+// Surrounding whitespace of XDG envvars and of each directory in XDG_CONFIG_DIRS and XDG_DATA_DIRS is trimmed,
+// and values that consist only of whitespace are treated as not defined.
+package xdgdir
@@ -0,0 +1,82 @@
+package xdgdir
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// dirJSON is resolved directory in JSON of App#MarshalJSON.
+type dirJSON struct {
+	Path   string `json:"path"`
+	Source string `json:"source,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// MarshalJSON returns app's resolved directories and where they come from as JSON, for tools in other languages.
+//
+// The schema is stable, like below. source is name of envvar, "layout" or "temp directory".
+// If directory cannot be resolved, path is empty and error has the reason.
+// Directories do not need to exist.
+//
+//	{
+//	  "name": "myapp",
+//	  "config": {"path": "/home/user/.config/myapp", "source": "HOME"},
+//	  "data": {"path": "/home/user/.local/share/myapp", "source": "HOME"},
+//	  "cache": {"path": "/tmp/cache/myapp", "source": "XDG_CACHE_HOME"},
+//	  "state": {"path": "/home/user/.local/state/myapp", "source": "HOME"},
+//	  "runtime": {"path": "/run/user/1000/myapp", "source": "XDG_RUNTIME_DIR"}
+//	}
+func (a App) MarshalJSON() ([]byte, error) {
+	v := struct {
+		Name    string  `json:"name"`
+		Config  dirJSON `json:"config"`
+		Data    dirJSON `json:"data"`
+		Cache   dirJSON `json:"cache"`
+		State   dirJSON `json:"state"`
+		Runtime dirJSON `json:"runtime"`
+	}{Name: a.Name}
+	dirs := []*dirJSON{&v.Config, &v.Data, &v.Cache, &v.State, &v.Runtime}
+	for i, k := range kindNames {
+		dir, err := a.dir(k.kind)
+		if err != nil {
+			dirs[i].Error = err.Error()
+			continue
+		}
+		dirs[i].Path = dir
+		dirs[i].Source = a.source(k.suffix, k.env)
+	}
+	return json.Marshal(v)
+}
+
+// DumpEnv returns effective XDG envvars of app as KEY=VALUE lines, that can be evaluated by shell scripts.
+//
+// Values are base directories that do not contain subdirectory for app, with defaults applied.
+// Envvars that cannot be resolved, for example because home directory is not found, are omitted.
+// Values are not quoted, so paths that contain whitespace or quotes need care in shell.
+func DumpEnv(app App) string {
+	var b strings.Builder
+	write := func(key, value string) {
+		if value != "" {
+			b.WriteString(key + "=" + value + "\n")
+		}
+	}
+	for _, h := range []struct {
+		env  string
+		home func() (string, error)
+	}{
+		{"XDG_CONFIG_HOME", app.configHome},
+		{"XDG_DATA_HOME", app.dataHome},
+		{"XDG_CACHE_HOME", app.cacheHome},
+		{"XDG_STATE_HOME", app.stateHome},
+	} {
+		dir, _ := h.home()
+		write(h.env, dir)
+	}
+	write("XDG_RUNTIME_DIR", app.anchor(buildRuntime(app.getenv, app.uid())))
+	sep := string(app.listSeparator())
+	for _, env := range []string{"XDG_CONFIG_DIRS", "XDG_DATA_DIRS"} {
+		dirs, _ := app.systemDirs(env)
+		write(env, strings.Join(dirs, sep))
+	}
+	return b.String()
+}
@@ -0,0 +1,63 @@
+package xdgdir
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestAppMarshalJSON(t *testing.T) {
+	env := []string{"XDG_CONFIG_HOME=conf", "HOME=home", "XDG_RUNTIME_DIR=run"}
+	b, err := json.Marshal(NewApp("test", WithEnviron(env)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actual map[string]interface{}
+	if err := json.Unmarshal(b, &actual); err != nil {
+		t.Fatal(err)
+	}
+	dir := func(p, source string) map[string]interface{} {
+		return map[string]interface{}{"path": p, "source": source}
+	}
+	expected := map[string]interface{}{
+		"name":    "test",
+		"config":  dir(path("conf", "test"), "XDG_CONFIG_HOME"),
+		"data":    dir(path("home", ".local", "share", "test"), "HOME"),
+		"cache":   dir(path("home", ".cache", "test"), "HOME"),
+		"state":   dir(path("home", ".local", "state", "test"), "HOME"),
+		"runtime": dir(path("run", "test"), "XDG_RUNTIME_DIR"),
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+
+	b, err = json.Marshal(NewApp("test", WithEnviron([]string{"XDG_RUNTIME_DIR=run"})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var noHome struct {
+		Config dirJSON `json:"config"`
+	}
+	if err := json.Unmarshal(b, &noHome); err != nil {
+		t.Fatal(err)
+	}
+	if noHome.Config.Path != "" || noHome.Config.Error != ErrNoHome.Error() {
+		t.Errorf("expected error of unresolved dir, but got %+v", noHome.Config)
+	}
+}
+
+func TestDumpEnv(t *testing.T) {
+	env := []string{"XDG_CONFIG_HOME=conf", "HOME=home", "XDG_RUNTIME_DIR=run", "XDG_DATA_DIRS=" + join("a", "b")}
+	sep := string(os.PathListSeparator)
+	expected := "XDG_CONFIG_HOME=conf\n" +
+		"XDG_DATA_HOME=" + path("home", ".local", "share") + "\n" +
+		"XDG_CACHE_HOME=" + path("home", ".cache") + "\n" +
+		"XDG_STATE_HOME=" + path("home", ".local", "state") + "\n" +
+		"XDG_RUNTIME_DIR=run\n" +
+		"XDG_CONFIG_DIRS=" + path("/etc", "xdg") + "\n" +
+		"XDG_DATA_DIRS=a" + sep + "b\n"
+	if actual := DumpEnv(NewApp("test", WithEnviron(env), WithGOOS("linux"))); actual != expected {
+		t.Errorf("expected %q, but got %q", expected, actual)
+	}
+}
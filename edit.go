@@ -0,0 +1,89 @@
+package xdgdir
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// EditConfigFile edits app's config file that has given name.
+//
+// 1. Reads current contents of the file (empty if the file does not exist).
+// 2. Passes them to edit.
+// 3. Writes the result back atomically. If edit returns error, the file is left untouched.
+//
+// Edits of same file are serialized within a process,
+// but concurrent edits from other processes are not guarded. Use EditConfigFileLocked for that.
+func (a App) EditConfigFile(name string, edit func(current []byte) ([]byte, error)) error {
+	fp, err := a.ConfigFile(name)
+	if err != nil {
+		return err
+	}
+
+	mu := lockPath(fp)
+	mu.Lock()
+	defer mu.Unlock()
+	return a.editFile(fp, edit)
+}
+
+// EditConfigFileLocked is like EditConfigFile, but also guards the edit against other processes
+// by advisory lock on sibling lock file {{name}}.lock.
+//
+// If the lock is not acquired within timeout, returns error that wraps ErrLocked.
+// The lock is flock on Unix and LockFileEx on Windows. On other platforms, such as Plan 9,
+// edits are serialized only within a process like EditConfigFile.
+// The lock file is always on the OS filesystem, even if App is created with WithFileSystem option, and is left after the edit.
+func (a App) EditConfigFileLocked(name string, timeout time.Duration, edit func(current []byte) ([]byte, error)) error {
+	fp, err := a.ConfigFile(name)
+	if err != nil {
+		return err
+	}
+
+	mu := lockPath(fp)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := a.osAccess(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fp), 0700); err != nil {
+		return err
+	}
+	lf, err := os.OpenFile(fp+".lock", os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer lf.Close()
+
+	deadline := time.Now().Add(timeout)
+	for {
+		ok, err := tryLockFile(lf)
+		if err != nil {
+			return err
+		}
+		if ok {
+			break
+		}
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("config file %s is %w", fp, ErrLocked)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	defer unlockFile(lf)
+	return a.editFile(fp, edit)
+}
+
+func (a App) editFile(fp string, edit func(current []byte) ([]byte, error)) error {
+	cur, err := readFile(a.fs(), fp)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	b, err := edit(cur)
+	if err != nil {
+		return err
+	}
+	return a.writeFileAtomic(fp, b)
+}
@@ -0,0 +1,103 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAppEditConfigFile(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	err := app.EditConfigFile("config.txt", func(cur []byte) ([]byte, error) {
+		if len(cur) != 0 {
+			t.Errorf("expected empty contents, but got %s", cur)
+		}
+		return []byte("a"), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = app.EditConfigFile("config.txt", func(cur []byte) ([]byte, error) {
+		return append(cur, 'b'), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fp, _ := app.ConfigFile("config.txt")
+	if s, _ := openFile(fp); s != "ab" {
+		t.Errorf("expected ab, but got %s", s)
+	}
+}
+
+func TestAppEditConfigFileWithError(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	fp, _ := app.ConfigFile("config.txt")
+	if err := app.EditConfigFile("config.txt", func([]byte) ([]byte, error) { return []byte("a"), nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	e := errors.New("edit failed")
+	err := app.EditConfigFile("config.txt", func([]byte) ([]byte, error) {
+		return []byte("broken"), e
+	})
+	if err != e {
+		t.Errorf("expected %v, but got %v", e, err)
+	}
+	if s, _ := openFile(fp); s != "a" {
+		t.Errorf("file should be untouched, but got %s", s)
+	}
+}
+
+func TestAppEditConfigFileConcurrently(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := app.EditConfigFile("count.txt", func(cur []byte) ([]byte, error) {
+				return append(cur, 'x'), nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	fp, _ := app.ConfigFile("count.txt")
+	b, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != 20 {
+		t.Errorf("expected 20 edits, but got %d", len(b))
+	}
+}
+
+func TestAppEditConfigFileLocked(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	for i := 0; i < 2; i++ {
+		err := app.EditConfigFileLocked("config.txt", time.Second, func(cur []byte) ([]byte, error) {
+			return append(cur, 'a'), nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	fp, _ := app.ConfigFile("config.txt")
+	if s, _ := openFile(fp); s != "aa" {
+		t.Errorf("expected aa, but got %s", s)
+	}
+}
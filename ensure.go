@@ -0,0 +1,159 @@
+package xdgdir
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// EnsureConfigDir creates app's config directory with 0700 permission if it does not exist, and returns its path.
+//
+// If App is created with WithLazyCreate option, the directory is not created here.
+func (a App) EnsureConfigDir() (string, error) {
+	return a.ensureDir(a.ConfigDir)
+}
+
+// EnsureDataDir creates app's data directory with 0700 permission if it does not exist, and returns its path.
+//
+// If App is created with WithLazyCreate option, the directory is not created here.
+func (a App) EnsureDataDir() (string, error) {
+	return a.ensureDir(a.DataDir)
+}
+
+// EnsureCacheDir creates app's cache directory with 0700 permission if it does not exist, and returns its path.
+//
+// If App is created with WithLazyCreate option, the directory is not created here.
+func (a App) EnsureCacheDir() (string, error) {
+	return a.ensureDir(a.CacheDir)
+}
+
+// EnsureConfigDirMode is like EnsureConfigDir, but also returns actual permission of the directory.
+//
+// The permission may differ from 0700 depending on umask, or when the directory already existed.
+// If App is created with WithLazyCreate option and the directory does not exist, returned mode is 0.
+func (a App) EnsureConfigDirMode() (string, os.FileMode, error) {
+	return a.ensureDirMode(a.ConfigDir)
+}
+
+// EnsureDataDirMode is like EnsureDataDir, but also returns actual permission of the directory.
+//
+// The permission may differ from 0700 depending on umask, or when the directory already existed.
+// If App is created with WithLazyCreate option and the directory does not exist, returned mode is 0.
+func (a App) EnsureDataDirMode() (string, os.FileMode, error) {
+	return a.ensureDirMode(a.DataDir)
+}
+
+// EnsureCacheDirMode is like EnsureCacheDir, but also returns actual permission of the directory.
+//
+// The permission may differ from 0700 depending on umask, or when the directory already existed.
+// If App is created with WithLazyCreate option and the directory does not exist, returned mode is 0.
+func (a App) EnsureCacheDirMode() (string, os.FileMode, error) {
+	return a.ensureDirMode(a.CacheDir)
+}
+
+func (a App) ensureDirMode(f func() (string, error)) (string, os.FileMode, error) {
+	dir, err := a.ensureDir(f)
+	if err != nil {
+		return "", 0, err
+	}
+	fi, err := a.fs().Stat(dir)
+	if errors.Is(err, fs.ErrNotExist) && a.opt().lazyCreate {
+		return dir, 0, nil
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	return dir, fi.Mode().Perm(), nil
+}
+
+func (a App) ensureDir(f func() (string, error)) (string, error) {
+	dir, err := f()
+	if err != nil {
+		return "", err
+	}
+	if a.opt().lazyCreate {
+		return dir, nil
+	}
+	if err := a.checkPathLen(dir); err != nil {
+		return "", err
+	}
+	if err := a.fs().MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	if a.opt().enforceDirMode {
+		if err := a.enforceDirMode(dir); err != nil {
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+// enforceDirMode tightens permission of dir to 0700 if it is looser.
+func (a App) enforceDirMode(dir string) error {
+	fi, err := a.fs().Stat(dir)
+	if err != nil {
+		return err
+	}
+	mode := fi.Mode().Perm()
+	if mode&^0700 == 0 {
+		return nil
+	}
+	if err := chmod(a.fs(), dir, 0700); errors.Is(err, errUnsupported) {
+		a.logf("xdgdir: permission of %s is %o, but cannot be tightened because FileSystem does not support Chmod", dir, mode)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	a.logf("xdgdir: tightened permission of %s from %o to %o", dir, mode, 0700)
+	return nil
+}
+
+// EnsureAllDirs creates app's config, data, cache and state directories with 0700 permission if they do not exist,
+// and returns their paths keyed by Kind.
+//
+// All directories are resolved before any is created. If creating a directory fails,
+// directories that are created by this call are removed and the error is returned.
+// Runtime directory is not created, because it has stricter requirements.
+func (a App) EnsureAllDirs() (map[Kind]string, error) {
+	kinds := []Kind{Config, Data, Cache, State}
+	for _, kind := range kinds {
+		if _, err := a.dir(kind); err != nil {
+			return nil, err
+		}
+	}
+
+	dirs := make(map[Kind]string, len(kinds))
+	var created []string
+	for _, kind := range kinds {
+		dir, _ := a.dir(kind)
+		_, statErr := a.fs().Stat(dir)
+		if _, err := a.ensureDir(func() (string, error) { return dir, nil }); err != nil {
+			for i := len(created) - 1; i >= 0; i-- {
+				a.fs().Remove(created[i])
+			}
+			return nil, err
+		}
+		if errors.Is(statErr, fs.ErrNotExist) {
+			created = append(created, dir)
+		}
+		dirs[kind] = dir
+	}
+	return dirs, nil
+}
+
+// EnsureRuntimeDir creates app's runtime directory with 0700 permission if it does not exist, and returns its path.
+//
+// If non-directory file already exists at the path, returns descriptive error instead of failing later
+// when app creates sockets or pid files in it.
+// If App is created with WithLazyCreate option, the directory is not created here.
+func (a App) EnsureRuntimeDir() (string, error) {
+	if err := a.validateName(); err != nil {
+		return "", err
+	}
+	dir := a.RuntimeDir()
+	if fi, err := a.fs().Stat(dir); err == nil && !fi.IsDir() {
+		return "", fmt.Errorf("runtime dir %s exists but is not directory", dir)
+	}
+	return a.ensureDir(func() (string, error) { return dir, nil })
+}
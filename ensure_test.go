@@ -0,0 +1,237 @@
+package xdgdir
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAppEnsureDir(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	os.Setenv("XDG_DATA_HOME", t.TempDir())
+	os.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	for _, f := range []func() (string, error){app.EnsureConfigDir, app.EnsureDataDir, app.EnsureCacheDir} {
+		dir, err := f()
+		if err != nil {
+			t.Fatal(err)
+		}
+		fi, err := os.Stat(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !fi.IsDir() {
+			t.Errorf("%s should be directory", dir)
+		}
+		if fi.Mode().Perm() != 0700 {
+			t.Errorf("expected 0700, but got %o", fi.Mode().Perm())
+		}
+	}
+}
+
+func TestAppEnsureDirWithLazyCreate(t *testing.T) {
+	app := NewApp("test", WithLazyCreate())
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	dir, err := app.EnsureConfigDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("%s should not be created", dir)
+	}
+
+	if err := app.EditConfigFile("config.txt", func([]byte) ([]byte, error) { return []byte("a"), nil }); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("%s should be created by write", dir)
+	}
+}
+
+func TestAppEnsureDirWithoutHome(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", "")
+	os.Setenv("HOME", "")
+	os.Setenv("USERPROFILE", "")
+
+	if _, err := app.EnsureConfigDir(); err == nil {
+		t.Error("should raise error, but not raised")
+	}
+}
+
+func TestAppEnsureDirMode(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	os.Setenv("XDG_DATA_HOME", t.TempDir())
+	os.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	for _, f := range []func() (string, os.FileMode, error){app.EnsureConfigDirMode, app.EnsureDataDirMode, app.EnsureCacheDirMode} {
+		dir, mode, err := f()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mode != 0700 {
+			t.Errorf("expected 0700, but got %o", mode)
+		}
+
+		if err := os.Chmod(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if _, mode, _ := f(); mode != 0755 {
+			t.Errorf("expected 0755 for existing dir, but got %o", mode)
+		}
+	}
+}
+
+func TestAppEnsureDirModeWithLazyCreate(t *testing.T) {
+	app := NewApp("test", WithLazyCreate())
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	dir, mode, err := app.EnsureConfigDirMode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode != 0 {
+		t.Errorf("expected 0, but got %o", mode)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("%s should not be created", dir)
+	}
+}
+
+func TestAppEnsureDirWithEnforceDirMode(t *testing.T) {
+	table := []struct {
+		opts     []Option
+		mode     os.FileMode
+		expected os.FileMode
+		logged   bool
+	}{
+		{nil, 0755, 0755, false},
+		{[]Option{WithEnforceDirMode()}, 0755, 0700, true},
+		{[]Option{WithEnforceDirMode()}, 0500, 0500, false},
+	}
+
+	for _, tbl := range table {
+		var logs []string
+		opts := append(tbl.opts, WithLogf(func(format string, v ...interface{}) {
+			logs = append(logs, format)
+		}))
+		app := NewApp("test", opts...)
+		os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		dir, _ := app.ConfigDir()
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chmod(dir, tbl.mode); err != nil {
+			t.Fatal(err)
+		}
+
+		_, mode, err := app.EnsureConfigDirMode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mode != tbl.expected {
+			t.Errorf("expected %o, but got %o", tbl.expected, mode)
+		}
+		if logged := len(logs) > 0; logged != tbl.logged {
+			t.Errorf("expected logged %v, but got %v", tbl.logged, logs)
+		}
+	}
+}
+
+func TestAppEnsureDirWithEnforceDirModeFileSystem(t *testing.T) {
+	fsys := newMemFS()
+	home := t.TempDir()
+	var logs []string
+	app := NewApp("test", WithEnviron([]string{"XDG_CONFIG_HOME=" + home}), WithFileSystem(fsys), WithEnforceDirMode(),
+		WithLogf(func(format string, v ...interface{}) {
+			logs = append(logs, fmt.Sprintf(format, v...))
+		}))
+	dir, _ := app.ConfigDir()
+	if err := fsys.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// memFS does not support Chmod, so the permission is left and not reported as tightened.
+	_, mode, err := app.EnsureConfigDirMode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode != 0755 {
+		t.Errorf("expected 755, but got %o", mode)
+	}
+	if len(logs) != 1 || strings.HasPrefix(logs[0], "xdgdir: tightened") {
+		t.Errorf("expected log that permission cannot be tightened, but got %v", logs)
+	}
+}
+
+func TestAppEnsureAllDirs(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	os.Setenv("XDG_DATA_HOME", t.TempDir())
+	os.Setenv("XDG_CACHE_HOME", t.TempDir())
+	os.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	for i := 0; i < 2; i++ {
+		dirs, err := app.EnsureAllDirs()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(dirs) != 4 {
+			t.Errorf("expected 4 dirs, but got %v", dirs)
+		}
+		for kind, dir := range dirs {
+			if expected, _ := app.dir(kind); dir != expected {
+				t.Errorf("expected %s, but got %s", expected, dir)
+			}
+			if !exists(dir) {
+				t.Errorf("%s should be created", dir)
+			}
+		}
+	}
+}
+
+func TestAppEnsureAllDirsWithError(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	os.Setenv("XDG_DATA_HOME", t.TempDir())
+	os.Setenv("XDG_CACHE_HOME", t.TempDir())
+	blocked := path(t.TempDir(), "file")
+	if err := os.WriteFile(blocked, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("XDG_STATE_HOME", blocked)
+
+	if _, err := app.EnsureAllDirs(); err == nil {
+		t.Error("should raise error, but not raised")
+	}
+	for _, f := range []func() (string, error){app.ConfigDir, app.DataDir, app.CacheDir} {
+		if dir, _ := f(); exists(dir) {
+			t.Errorf("%s should be removed", dir)
+		}
+	}
+}
+
+func TestAppEnsureRuntimeDir(t *testing.T) {
+	os.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	app := NewApp("test")
+
+	dir, err := app.EnsureRuntimeDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := os.Stat(dir); err != nil || !fi.IsDir() || fi.Mode().Perm() != 0700 {
+		t.Errorf("%s should be created with 0700, but got %v, %v", dir, fi, err)
+	}
+
+	blocked := NewApp("blocked")
+	if err := os.WriteFile(blocked.RuntimeDir(), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := blocked.EnsureRuntimeDir(); err == nil || !strings.Contains(err.Error(), "is not directory") {
+		t.Errorf("expected error for non-directory, but got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package xdgdir
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// Errors that are returned by App's methods. Returned errors wrap them, so test them with errors.Is.
+var (
+	// ErrNotFound is returned when file is not found in any directory of search path.
+	//
+	// Errors that wrap ErrNotFound also satisfy errors.Is(err, fs.ErrNotExist), and errors of read methods
+	// that satisfy errors.Is(err, fs.ErrNotExist) also satisfy errors.Is(err, ErrNotFound),
+	// so callers can use either.
+	ErrNotFound error = notFoundError{}
+
+	// ErrInvalidName is returned when app name or file name cannot be used as path.
+	ErrInvalidName = errors.New("invalid name")
+
+	// ErrReadOnly is returned when App cannot write files, because it is configured to be read-only.
+	ErrReadOnly = errors.New("read-only")
+
+	// ErrLocked is returned when file is locked by another process.
+	ErrLocked = errors.New("locked")
+
+	// ErrAlreadyExists is returned when file that would be overwritten already exists.
+	ErrAlreadyExists = errors.New("already exists")
+
+	// ErrNoHome is returned when none of XDG envvar, HOME and USERPROFILE envvars is defined.
+	ErrNoHome = errors.New("home directory not found")
+
+	// ErrRuntimeDirUnset is returned by App#RequireRuntimeDir when XDG_RUNTIME_DIR envvar is not defined.
+	ErrRuntimeDirUnset = errors.New("XDG_RUNTIME_DIR is not defined")
+)
+
+type notFoundError struct{}
+
+func (notFoundError) Error() string {
+	return "not found"
+}
+
+func (notFoundError) Is(target error) bool {
+	return target == fs.ErrNotExist
+}
+
+// notExistError is error that satisfies fs.ErrNotExist and is made to satisfy ErrNotFound too.
+type notExistError struct {
+	err error
+}
+
+func (e *notExistError) Error() string {
+	return e.err.Error()
+}
+
+func (e *notExistError) Unwrap() []error {
+	return []error{e.err, ErrNotFound}
+}
+
+// wrapNotExist makes err that satisfies fs.ErrNotExist also satisfy ErrNotFound, and returns other errors as is.
+func wrapNotExist(err error) error {
+	if err == nil || !errors.Is(err, fs.ErrNotExist) || errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return &notExistError{err}
+}
+
+// NameTooLongError is returned when name or path is longer than the limit of filesystems,
+// before it fails deep in syscall with ENAMETOOLONG.
+//
+// NameTooLongError wraps ErrInvalidName.
+type NameTooLongError struct {
+	// Name is the name or path that is too long.
+	Name string
+	// Max is the maximum length in bytes.
+	Max int
+}
+
+func (e *NameTooLongError) Error() string {
+	return fmt.Sprintf("%v: %q is %d bytes, longer than %d bytes", ErrInvalidName, e.Name, len(e.Name), e.Max)
+}
+
+// Unwrap returns ErrInvalidName.
+func (e *NameTooLongError) Unwrap() error {
+	return ErrInvalidName
+}
+
+// NotFoundError is returned when none of candidate files is found in search path.
+//
+// NotFoundError wraps ErrNotFound.
+type NotFoundError struct {
+	// Names are candidate file names that are tried.
+	Names []string
+	// Dirs are directories that are searched.
+	Dirs []string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("none of %s is found in %s", strings.Join(e.Names, ", "), strings.Join(e.Dirs, ", "))
+}
+
+// Unwrap returns ErrNotFound.
+func (e *NotFoundError) Unwrap() error {
+	return ErrNotFound
+}
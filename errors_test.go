@@ -0,0 +1,88 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "a"))
+	os.Setenv("XDG_CONFIG_DIRS", path("testdata", "b"))
+	os.Setenv("XDG_DATA_HOME", path("testdata", "a"))
+	os.Setenv("XDG_DATA_DIRS", path("testdata", "b"))
+	app := NewApp("test")
+	noHome := NewApp("test", WithEnviron(nil))
+	src, dst := NewApp("src"), NewApp("dst")
+
+	table := []struct {
+		name     string
+		f        func() error
+		expected error
+	}{
+		{"FindConfigFile", func() error { _, err := app.FindConfigFile("none.txt"); return err }, ErrNotFound},
+		{"FindDataFile", func() error { _, err := app.FindDataFile("none.txt"); return err }, ErrNotFound},
+		{"FindAllConfigFiles", func() error { _, err := app.FindAllConfigFiles("none.txt"); return err }, ErrNotFound},
+		{"FindConfigFileFunc", func() error {
+			_, err := app.FindConfigFileFunc(func(string, string, fs.FileInfo) bool { return false })
+			return err
+		}, ErrNotFound},
+		{"FindThemedResource", func() error { _, err := app.FindThemedResource("icons", "dark", "none.png"); return err }, ErrNotFound},
+		{"OpenDataFileMaybeGzip", func() error { _, err := app.OpenDataFileMaybeGzip("none.txt"); return err }, ErrNotFound},
+		{"ConfigFile", func() error { _, err := app.ConfigFile("a\x00"); return err }, ErrInvalidName},
+		{"NewApp", func() error { _, err := NewApp("").ConfigDir(); return err }, ErrInvalidName},
+		{"ConfigDir", func() error { _, err := noHome.ConfigDir(); return err }, ErrNoHome},
+		{"RequireRuntimeDir", func() error { _, err := noHome.RequireRuntimeDir(); return err }, ErrRuntimeDirUnset},
+		{"CopyAppTree", func() error {
+			os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			defer os.Setenv("XDG_CONFIG_HOME", path("testdata", "a"))
+			src.WriteConfigFile("a.txt", nil)
+			dst.WriteConfigFile("a.txt", nil)
+			return CopyAppTree(src, dst, Config)
+		}, ErrAlreadyExists},
+	}
+	for _, tbl := range table {
+		if err := tbl.f(); !errors.Is(err, tbl.expected) {
+			t.Errorf("%s: expected %v, but got %v", tbl.name, tbl.expected, err)
+		}
+	}
+}
+
+func TestNotFoundErrors(t *testing.T) {
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "a"))
+	os.Setenv("XDG_CONFIG_DIRS", path("testdata", "b"))
+	os.Setenv("XDG_DATA_HOME", path("testdata", "a"))
+	os.Setenv("XDG_DATA_DIRS", path("testdata", "b"))
+	os.Setenv("XDG_STATE_HOME", t.TempDir())
+	app := NewApp("test")
+
+	table := []struct {
+		name string
+		f    func() error
+	}{
+		{"FindConfigFile", func() error { _, err := app.FindConfigFile("none.txt"); return err }},
+		{"FindConfigFileInfo", func() error { _, _, err := app.FindConfigFileInfo("none.txt"); return err }},
+		{"FindDataFile", func() error { _, err := app.FindDataFile("none.txt"); return err }},
+		{"FindAllDataFiles", func() error { _, err := app.FindAllDataFiles("none.txt"); return err }},
+		{"FindFirstConfigFile", func() error { _, err := app.FindFirstConfigFile("none.txt"); return err }},
+		{"FindResource", func() error { _, err := app.FindResource("none.txt"); return err }},
+		{"FindStateFileOrData", func() error { _, _, err := app.FindStateFileOrData("none.txt"); return err }},
+		{"FindThemedResource", func() error { _, err := app.FindThemedResource("icons", "dark", "none.png"); return err }},
+		{"ReadConfigFileFS", func() error { _, err := app.ReadConfigFileFS("none.txt"); return err }},
+		{"OpenDataFileMaybeGzip", func() error { _, err := app.OpenDataFileMaybeGzip("none.txt"); return err }},
+		{"OpenLatestStateFile", func() error { _, err := app.OpenLatestStateFile("none.txt"); return err }},
+		{"ReadCurrentData", func() error { _, err := app.ReadCurrentData("none"); return err }},
+		{"ConfigFileMode", func() error { _, err := app.ConfigFileMode("none.txt"); return err }},
+		{"SecureConfigFile", func() error { return app.SecureConfigFile("none.txt") }},
+	}
+	for _, tbl := range table {
+		err := tbl.f()
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: expected %v, but got %v", tbl.name, ErrNotFound, err)
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("%s: expected %v, but got %v", tbl.name, fs.ErrNotExist, err)
+		}
+	}
+}
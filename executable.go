@@ -0,0 +1,61 @@
+package xdgdir
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var executable struct {
+	once sync.Once
+	dir  string
+	err  error
+}
+
+// WithExecutableConfigDir adds directory that contains the running executable to config search path,
+// so that self-contained distribution can ship config next to the binary.
+//
+// If subdir is not empty, its subdirectory such as "config" is added instead.
+// The directory is added as extra config directory: it is appended after XDG_CONFIG_DIRS, so users can override
+// bundled config in their XDG config, or prepended before app's config directory with WithPrependExtraDirs option.
+//
+// The executable path is resolved once per process, following symlinks. If it cannot be resolved,
+// the directory is not added. With WithNoFilesystemAccess option, this option has no effect.
+func WithExecutableConfigDir(subdir string) Option {
+	return func(a *App) {
+		a.opts.executableConfigDir = true
+		a.opts.executableConfigSubdir = subdir
+	}
+}
+
+// executableDir returns directory of the running executable whose symlinks are evaluated.
+func executableDir() (string, error) {
+	executable.once.Do(func() {
+		exe, err := os.Executable()
+		if err != nil {
+			executable.err = err
+			return
+		}
+		exe, err = filepath.EvalSymlinks(exe)
+		if err != nil {
+			executable.err = err
+			return
+		}
+		executable.dir = filepath.Dir(exe)
+	})
+	return executable.dir, executable.err
+}
+
+// extraConfigDirs returns extra config directories including executable's directory.
+func (a App) extraConfigDirs() []string {
+	extras := a.opt().extraDirs["XDG_CONFIG_DIRS"]
+	if !a.opt().executableConfigDir || a.opt().noFilesystemAccess {
+		return extras
+	}
+	dir, err := executableDir()
+	if err != nil {
+		a.logf("xdgdir: executable config directory is ignored: %v", err)
+		return extras
+	}
+	return append(extras[:len(extras):len(extras)], filepath.Join(dir, a.opt().executableConfigSubdir))
+}
@@ -0,0 +1,34 @@
+package xdgdir
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAppWithExecutableConfigDir(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Skip(err)
+	}
+	exe, _ = filepath.EvalSymlinks(exe)
+	dir := filepath.Dir(exe)
+	env := []string{"XDG_CONFIG_HOME=a", "XDG_CONFIG_DIRS=b"}
+
+	table := []struct {
+		opts     []Option
+		expected []string
+	}{
+		{[]Option{WithExecutableConfigDir("")}, []string{path("a", "test"), path("b", "test"), dir}},
+		{[]Option{WithExecutableConfigDir("config")}, []string{path("a", "test"), path("b", "test"), path(dir, "config")}},
+		{[]Option{WithExecutableConfigDir(""), WithPrependExtraDirs()}, []string{dir, path("a", "test"), path("b", "test")}},
+		{[]Option{WithExecutableConfigDir(""), WithNoFilesystemAccess()}, []string{path("a", "test"), path("b", "test")}},
+	}
+	for _, tbl := range table {
+		app := NewApp("test", append(tbl.opts, WithEnviron(env))...)
+		if dirs := app.ConfigDirs(); !reflect.DeepEqual(dirs, tbl.expected) {
+			t.Errorf("expected %v, but got %v", tbl.expected, dirs)
+		}
+	}
+}
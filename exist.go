@@ -0,0 +1,26 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+)
+
+// ConfigFilesExist reports whether each of given files or directories exists in app's config directory.
+//
+// Unlike FindConfigFile, only directory that is returned App#ConfigDir is checked.
+// Errors other than not found, such as permission denied, are returned instead of being reported as false.
+func (a App) ConfigFilesExist(names ...string) (map[string]bool, error) {
+	result := make(map[string]bool, len(names))
+	for _, name := range names {
+		fp, err := a.ConfigFile(name)
+		if err != nil {
+			return nil, err
+		}
+		_, err = a.fs().Stat(fp)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		result[name] = err == nil
+	}
+	return result, nil
+}
@@ -0,0 +1,40 @@
+package xdgdir
+
+import (
+	"io/fs"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestAppConfigFilesExist(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "c"))
+
+	result, err := app.ConfigFilesExist("ccc.txt", "d", "none.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]bool{"ccc.txt": true, "d": true, "none.txt": false}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, but got %v", expected, result)
+	}
+
+	if _, err := app.ConfigFilesExist("a\x00"); err == nil {
+		t.Error("should raise error for invalid name, but not raised")
+	}
+
+	denied := NewApp("test", WithFileSystem(deniedFS{}))
+	if _, err := denied.ConfigFilesExist("ccc.txt"); err == nil {
+		t.Error("should raise error for permission denied, but not raised")
+	}
+}
+
+// deniedFS is FileSystem whose Stat always fails with permission denied.
+type deniedFS struct {
+	osFileSystem
+}
+
+func (deniedFS) Stat(name string) (fs.FileInfo, error) {
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrPermission}
+}
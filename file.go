@@ -0,0 +1,135 @@
+package xdgdir
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+)
+
+var pathLocks = struct {
+	sync.Mutex
+	m map[string]*sync.Mutex
+}{m: make(map[string]*sync.Mutex)}
+
+// lockPath returns mutex that serializes operations on given path within this process.
+func lockPath(path string) *sync.Mutex {
+	pathLocks.Lock()
+	defer pathLocks.Unlock()
+	mu, ok := pathLocks.m[path]
+	if !ok {
+		mu = &sync.Mutex{}
+		pathLocks.m[path] = mu
+	}
+	return mu
+}
+
+var tempSeq uint64
+
+// tempName returns name of temporary file for path that is unique among processes.
+func tempName(path string) string {
+	seq := atomic.AddUint64(&tempSeq, 1)
+	return filepath.Join(filepath.Dir(path), fmt.Sprintf(".%s.%d.%d.tmp", filepath.Base(path), os.Getpid(), seq))
+}
+
+// writeFileAtomic writes data to temporary file in same directory and renames it to path,
+// so readers never see partially written file.
+//
+// The temporary file is fsynced before the rename, and the containing directory is fsynced after the rename,
+// so that both the contents and the rename itself survive a crash on filesystems like ext4 and xfs.
+func (a App) writeFileAtomic(path string, data []byte) error {
+	return a.writeFileAtomicFrom(path, bytes.NewReader(data))
+}
+
+// writeFileAtomicFrom is like writeFileAtomic, but streams contents from r.
+func (a App) writeFileAtomicFrom(path string, r io.Reader) error {
+	// Temporary file has longer name than path.
+	tmp := tempName(path)
+	if err := a.checkPathLen(tmp); err != nil {
+		return err
+	}
+	fsys := a.fs()
+	dir := filepath.Dir(path)
+	if err := fsys.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	f, err := fsys.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err := writeAndSync(f, r); err != nil {
+		fsys.Remove(tmp)
+		return err
+	}
+	if fi, err := fsys.Stat(path); err == nil {
+		if err := chmod(fsys, tmp, fi.Mode().Perm()); err != nil && !errors.Is(err, errUnsupported) {
+			fsys.Remove(tmp)
+			return err
+		}
+	}
+	if err := fsys.Rename(tmp, path); err != nil {
+		fsys.Remove(tmp)
+		return err
+	}
+	if err := a.syncDir(dir); err != nil {
+		return err
+	}
+	return a.backup(path)
+}
+
+// writeFileDirect writes data to path in place, without fsync.
+// Unlike writeFileAtomic, readers may see partially written file and the contents may be lost on crash.
+func (a App) writeFileDirect(path string, data []byte) error {
+	if err := a.checkPathLen(path); err != nil {
+		return err
+	}
+	fsys := a.fs()
+	if err := fsys.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := fsys.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeAndSync(f File, r io.Reader) error {
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// syncDir fsyncs directory to make renames in it durable.
+// This is no-op on Windows, where directories cannot be fsynced, and on filesystems whose directories cannot be synced.
+func (a App) syncDir(dir string) error {
+	if a.goos() == "windows" {
+		return nil
+	}
+	d, err := a.fs().Open(dir)
+	if err != nil {
+		return err
+	}
+	if s, ok := d.(interface{ Sync() error }); ok {
+		if err := s.Sync(); err != nil {
+			d.Close()
+			return err
+		}
+	}
+	return d.Close()
+}
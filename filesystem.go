@@ -0,0 +1,284 @@
+package xdgdir
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FileSystem is filesystem that App accesses files through.
+//
+// FileSystem may also implement optional methods Chmod, Lstat, Symlink, Readlink and RemoveAll
+// with the same signatures as os package, and OpenFile(name string, flag int, perm fs.FileMode) (File, error).
+// If it does not, App emulates them with the methods above, or treats the filesystem as one without symlinks.
+type FileSystem interface {
+	// Stat returns file info of named file.
+	Stat(name string) (fs.FileInfo, error)
+	// Open opens named file or directory for reading.
+	Open(name string) (fs.File, error)
+	// Create creates or truncates named file with 0600 permission for writing.
+	Create(name string) (File, error)
+	// MkdirAll creates directory and its parents with given permission.
+	MkdirAll(path string, perm fs.FileMode) error
+	// ReadDir returns entries of named directory sorted by name.
+	// If reading fails partway, it returns entries that are read so far with the error, like os.ReadDir.
+	ReadDir(name string) ([]fs.DirEntry, error)
+	// Rename renames oldpath to newpath, replacing newpath if it exists.
+	Rename(oldpath, newpath string) error
+	// Remove removes named file or empty directory.
+	Remove(name string) error
+}
+
+// File is writable file that FileSystem#Create returns.
+type File interface {
+	io.Writer
+	io.Closer
+	// Sync commits written contents to stable storage.
+	Sync() error
+}
+
+// osFileSystem is FileSystem that is backed by os package.
+type osFileSystem struct{}
+
+func (osFileSystem) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (osFileSystem) Open(name string) (fs.File, error) {
+	return os.Open(name)
+}
+
+func (osFileSystem) Create(name string) (File, error) {
+	return os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+}
+
+func (osFileSystem) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+func (osFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+func (osFileSystem) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
+
+func (osFileSystem) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (osFileSystem) Chmod(name string, mode fs.FileMode) error {
+	return os.Chmod(name, mode)
+}
+
+func (osFileSystem) OpenFile(name string, flag int, perm fs.FileMode) (File, error) {
+	return os.OpenFile(name, flag, perm)
+}
+
+func (osFileSystem) Lstat(name string) (fs.FileInfo, error) {
+	return os.Lstat(name)
+}
+
+func (osFileSystem) Symlink(oldname, newname string) error {
+	return os.Symlink(oldname, newname)
+}
+
+func (osFileSystem) Readlink(name string) (string, error) {
+	return os.Readlink(name)
+}
+
+func (osFileSystem) RemoveAll(path string) error {
+	return os.RemoveAll(path)
+}
+
+// errUnsupported is wrapped by errors of optional FileSystem methods that FileSystem does not implement.
+// This is like errors.ErrUnsupported, which is not available in Go 1.20.
+var errUnsupported = errors.New("operation not supported by FileSystem")
+
+// errNoFilesystemAccess is returned by file operations of App that is created with WithNoFilesystemAccess option.
+var errNoFilesystemAccess = fmt.Errorf("filesystem access is disabled: %w", ErrReadOnly)
+
+// noFileSystem is FileSystem whose operations always fail without touching disk.
+type noFileSystem struct{}
+
+func (noFileSystem) Stat(name string) (fs.FileInfo, error) {
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: errNoFilesystemAccess}
+}
+
+func (noFileSystem) Open(name string) (fs.File, error) {
+	return nil, &fs.PathError{Op: "open", Path: name, Err: errNoFilesystemAccess}
+}
+
+func (noFileSystem) Create(name string) (File, error) {
+	return nil, &fs.PathError{Op: "create", Path: name, Err: errNoFilesystemAccess}
+}
+
+func (noFileSystem) MkdirAll(path string, perm fs.FileMode) error {
+	return &fs.PathError{Op: "mkdir", Path: path, Err: errNoFilesystemAccess}
+}
+
+func (noFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	return nil, &fs.PathError{Op: "readdir", Path: name, Err: errNoFilesystemAccess}
+}
+
+func (noFileSystem) Rename(oldpath, newpath string) error {
+	return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: errNoFilesystemAccess}
+}
+
+func (noFileSystem) Remove(name string) error {
+	return &fs.PathError{Op: "remove", Path: name, Err: errNoFilesystemAccess}
+}
+
+// osAccess returns error if App is created with WithNoFilesystemAccess option.
+// Methods that access the OS filesystem directly, bypassing App#fs, must check it first.
+func (a App) osAccess() error {
+	if a.opt().noFilesystemAccess {
+		return errNoFilesystemAccess
+	}
+	return nil
+}
+
+func (a App) fs() FileSystem {
+	if a.opt().noFilesystemAccess {
+		return noFileSystem{}
+	}
+	var fsys FileSystem = osFileSystem{}
+	if a.opt().fileSystem != nil {
+		fsys = a.opt().fileSystem
+	}
+	if a.opt().retryAttempts > 1 {
+		fsys = retryFS{fsys: fsys, attempts: a.opt().retryAttempts, backoff: a.opt().retryBackoff}
+	}
+	return fsys
+}
+
+// chmod changes mode of named file if fsys supports Chmod(name string, mode fs.FileMode) error method,
+// and returns error wrapping errUnsupported otherwise.
+func chmod(fsys FileSystem, name string, mode fs.FileMode) error {
+	if c, ok := fsys.(interface {
+		Chmod(name string, mode fs.FileMode) error
+	}); ok {
+		return c.Chmod(name, mode)
+	}
+	return &fs.PathError{Op: "chmod", Path: name, Err: errUnsupported}
+}
+
+// lstat returns file info of named file without following symlink if fsys supports Lstat method,
+// and falls back to Stat otherwise.
+func lstat(fsys FileSystem, name string) (fs.FileInfo, error) {
+	fi, err := lstatNoFollow(fsys, name)
+	if errors.Is(err, errUnsupported) {
+		return fsys.Stat(name)
+	}
+	return fi, err
+}
+
+// lstatNoFollow is like lstat, but returns error wrapping errUnsupported instead of falling back to Stat,
+// for callers that must not follow symlinks.
+func lstatNoFollow(fsys FileSystem, name string) (fs.FileInfo, error) {
+	if l, ok := fsys.(interface {
+		Lstat(name string) (fs.FileInfo, error)
+	}); ok {
+		return l.Lstat(name)
+	}
+	return nil, &fs.PathError{Op: "lstat", Path: name, Err: errUnsupported}
+}
+
+// symlink creates newname as symlink to oldname if fsys supports Symlink method,
+// and returns error wrapping errUnsupported otherwise.
+func symlink(fsys FileSystem, oldname, newname string) error {
+	if s, ok := fsys.(interface {
+		Symlink(oldname, newname string) error
+	}); ok {
+		return s.Symlink(oldname, newname)
+	}
+	return &os.LinkError{Op: "symlink", Old: oldname, New: newname, Err: errUnsupported}
+}
+
+// readlink returns destination of named symlink if fsys supports Readlink method,
+// and returns error wrapping errUnsupported otherwise.
+func readlink(fsys FileSystem, name string) (string, error) {
+	if r, ok := fsys.(interface {
+		Readlink(name string) (string, error)
+	}); ok {
+		return r.Readlink(name)
+	}
+	return "", &fs.PathError{Op: "readlink", Path: name, Err: errUnsupported}
+}
+
+// removeAll removes path and its children if fsys supports RemoveAll method,
+// and removes them one by one with ReadDir and Remove otherwise.
+// Like os.RemoveAll, it returns nil if path does not exist.
+func removeAll(fsys FileSystem, path string) error {
+	if r, ok := fsys.(interface {
+		RemoveAll(path string) error
+	}); ok {
+		return r.RemoveAll(path)
+	}
+	fi, err := lstat(fsys, path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		entries, err := fsys.ReadDir(path)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := removeAll(fsys, filepath.Join(path, e.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return ignoreNotExist(fsys.Remove(path))
+}
+
+// appendFile appends data to named file, creating it with 0600 permission if it does not exist.
+// If fsys supports OpenFile method, the file is opened in append mode.
+// Otherwise the whole file is read and written again with Create, which is not safe against concurrent writers.
+func appendFile(fsys FileSystem, name string, data []byte) error {
+	if o, ok := fsys.(interface {
+		OpenFile(name string, flag int, perm fs.FileMode) (File, error)
+	}); ok {
+		f, err := o.OpenFile(name, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+		if err == nil {
+			if _, err := f.Write(data); err != nil {
+				f.Close()
+				return err
+			}
+			return f.Close()
+		}
+		if !errors.Is(err, errUnsupported) {
+			return err
+		}
+	}
+	b, err := readFile(fsys, name)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	f, err := fsys.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, data...)); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func readFile(fsys FileSystem, name string) ([]byte, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
@@ -0,0 +1,193 @@
+package xdgdir
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// memFS is in-memory FileSystem for tests.
+type memFS struct {
+	m fstest.MapFS
+}
+
+func newMemFS() *memFS {
+	return &memFS{m: fstest.MapFS{}}
+}
+
+func (m *memFS) key(name string) string {
+	k := strings.TrimPrefix(filepath.ToSlash(filepath.Clean(name)), "/")
+	if k == "" {
+		return "."
+	}
+	return k
+}
+
+func (m *memFS) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(m.m, m.key(name))
+}
+
+// Lstat is same as Stat, because memFS has no symlinks.
+func (m *memFS) Lstat(name string) (fs.FileInfo, error) {
+	return m.Stat(name)
+}
+
+func (m *memFS) Open(name string) (fs.File, error) {
+	return m.m.Open(m.key(name))
+}
+
+func (m *memFS) Create(name string) (File, error) {
+	return &memFile{fs: m, key: m.key(name)}, nil
+}
+
+func (m *memFS) MkdirAll(path string, perm fs.FileMode) error {
+	for k := m.key(path); k != "."; k = filepathDir(k) {
+		if f, ok := m.m[k]; ok {
+			if !f.Mode.IsDir() {
+				return &fs.PathError{Op: "mkdir", Path: path, Err: fs.ErrExist}
+			}
+			continue
+		}
+		m.m[k] = &fstest.MapFile{Mode: fs.ModeDir | perm}
+	}
+	return nil
+}
+
+func (m *memFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(m.m, m.key(name))
+}
+
+func (m *memFS) Rename(oldpath, newpath string) error {
+	f, ok := m.m[m.key(oldpath)]
+	if !ok {
+		return &fs.PathError{Op: "rename", Path: oldpath, Err: fs.ErrNotExist}
+	}
+	delete(m.m, m.key(oldpath))
+	m.m[m.key(newpath)] = f
+	// Children of directory are moved too.
+	prefix := m.key(oldpath) + "/"
+	for k, c := range m.m {
+		if rest, ok := strings.CutPrefix(k, prefix); ok {
+			delete(m.m, k)
+			m.m[m.key(newpath)+"/"+rest] = c
+		}
+	}
+	return nil
+}
+
+func (m *memFS) Remove(name string) error {
+	if _, ok := m.m[m.key(name)]; !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	delete(m.m, m.key(name))
+	return nil
+}
+
+func filepathDir(k string) string {
+	i := strings.LastIndexByte(k, '/')
+	if i < 0 {
+		return "."
+	}
+	return k[:i]
+}
+
+type memFile struct {
+	bytes.Buffer
+	fs  *memFS
+	key string
+}
+
+func (f *memFile) Sync() error {
+	return nil
+}
+
+func (f *memFile) Close() error {
+	f.fs.m[f.key] = &fstest.MapFile{Data: f.Bytes(), Mode: 0600}
+	return nil
+}
+
+func TestAppWithFileSystem(t *testing.T) {
+	fsys := newMemFS()
+	app := NewApp("test", WithFileSystem(fsys))
+	home := t.TempDir()
+	os.Setenv("XDG_CONFIG_HOME", home)
+	os.Setenv("XDG_CONFIG_DIRS", path(home, "sys"))
+
+	if first, _ := app.IsFirstRun(); !first {
+		t.Error("expected first run")
+	}
+	if err := app.WriteConfigFile("config.txt", []byte("a")); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.EditConfigFile("config.txt", func(cur []byte) ([]byte, error) {
+		return append(cur, 'b'), nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := app.ReadConfigFileFS("config.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "ab" {
+		t.Errorf("expected ab, but got %s", b)
+	}
+	f, err := app.FindConfigFile("config.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := path(home, "test", "config.txt"); f != expected {
+		t.Errorf("expected %s, but got %s", expected, f)
+	}
+	if first, _ := app.IsFirstRun(); first {
+		t.Error("expected not first run")
+	}
+
+	if _, err := os.Stat(path(home, "test")); !os.IsNotExist(err) {
+		t.Error("OS filesystem should not be touched")
+	}
+}
+
+func TestAppWithNoFilesystemAccess(t *testing.T) {
+	home := t.TempDir()
+	os.Setenv("XDG_CONFIG_HOME", home)
+	os.Setenv("XDG_CONFIG_DIRS", path("testdata", "c"))
+	os.Setenv("XDG_CACHE_HOME", home)
+	os.Setenv("XDG_STATE_HOME", home)
+	app := NewApp("test", WithNoFilesystemAccess())
+
+	if dir, err := app.ConfigDir(); err != nil || dir != path(home, "test") {
+		t.Errorf("expected %s, but got %s, %v", path(home, "test"), dir, err)
+	}
+	for _, e := range app.ConfigSearchPath() {
+		if e.Exists || e.Readable {
+			t.Errorf("%s should be reported as not existing", e.Path)
+		}
+	}
+	if _, err := app.FindConfigFile("ccc.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected %v, but got %v", ErrNotFound, err)
+	}
+
+	table := []struct {
+		name string
+		f    func() error
+	}{
+		{"WriteConfigFile", func() error { return app.WriteConfigFile("a.txt", nil) }},
+		{"ReadConfigFileFS", func() error { _, err := app.ReadConfigFileFS("ccc.txt"); return err }},
+		{"TempCacheFile", func() error { _, err := app.TempCacheFile("*"); return err }},
+		{"AppendStateFileRotating", func() error { return app.AppendStateFileRotating("log", nil, 1, 1) }},
+	}
+	for _, tbl := range table {
+		if err := tbl.f(); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s: expected %v, but got %v", tbl.name, ErrReadOnly, err)
+		}
+	}
+	if entries, _ := os.ReadDir(home); len(entries) != 0 {
+		t.Errorf("nothing should be created, but got %v", entries)
+	}
+}
@@ -0,0 +1,75 @@
+package xdgdir
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+)
+
+// FindConfigFileFunc finds first config file for which match returns true.
+//
+// Directories are traversed in same order as FindConfigFile:
+//
+// 1. Directory that is returned App#ConfigDir.
+// 2. Directories that are defined at XDG_CONFIG_DIRS envvar.
+//
+// match is called for each entry of each directory (not recursively) in lexical order of names,
+// with the directory, the entry name and its file info.
+// Directories that do not exist are skipped.
+func (a App) FindConfigFileFunc(match func(dir, name string, info fs.FileInfo) bool) (string, error) {
+	d, _ := a.ConfigDir()
+	for _, dir := range a.dirsForSearch(d, "XDG_CONFIG_DIRS") {
+		if dir == "" {
+			continue
+		}
+		entries, err := a.fs().ReadDir(dir)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return "", err
+		}
+		for _, e := range entries {
+			info, err := e.Info()
+			if err != nil {
+				continue
+			}
+			if match(dir, e.Name(), info) {
+				return filepath.Join(dir, e.Name()), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("config file that matches is %w", ErrNotFound)
+}
+
+// FindFirstConfigFile finds first existing config file among candidate names, such as
+// "config.toml", "config.yaml" and "config.json".
+//
+// Directories are searched in same order as FindConfigFile, and in each directory,
+// candidates are tried in given order before next directory. So config.json in App#ConfigDir
+// precedes config.toml in directories of XDG_CONFIG_DIRS.
+//
+// Unlike FindConfigFile, names are alternatives, not path elements.
+// If no candidate is found, returns *NotFoundError that lists candidates and searched directories.
+func (a App) FindFirstConfigFile(names ...string) (string, error) {
+	if err := a.validateName(); err != nil {
+		return "", err
+	}
+	for _, name := range names {
+		if err := ValidateName(name); err != nil {
+			return "", err
+		}
+	}
+
+	dirs := a.ConfigDirs()
+	for _, dir := range dirs {
+		for _, name := range names {
+			fp := filepath.Join(dir, name)
+			if _, err := a.fs().Stat(fp); err == nil {
+				return fp, nil
+			}
+		}
+	}
+	return "", &NotFoundError{Names: names, Dirs: dirs}
+}
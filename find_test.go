@@ -0,0 +1,106 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAppFindConfigFileFunc(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "a"))
+	os.Setenv("XDG_CONFIG_DIRS", join(path("testdata", "b"), path("testdata", "c")))
+
+	table := []struct {
+		exts     []string
+		expected string
+		err      bool
+	}{
+		{[]string{".txt"}, path("testdata", "a", "test", "aaa.txt"), false},
+		{[]string{".toml", ".json"}, "", true},
+	}
+	for _, tbl := range table {
+		f, err := app.FindConfigFileFunc(func(dir, name string, info fs.FileInfo) bool {
+			for _, ext := range tbl.exts {
+				if filepath.Ext(name) == ext && info.Mode().IsRegular() {
+					return true
+				}
+			}
+			return false
+		})
+		if tbl.err {
+			if err == nil {
+				t.Error("should raise error, but not raised")
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+		}
+		if f != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, f)
+		}
+	}
+}
+
+func TestAppFindConfigFileFuncOrder(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "a"))
+	os.Setenv("XDG_CONFIG_DIRS", join(path("testdata", "b"), path("testdata", "c")))
+
+	var visited []string
+	app.FindConfigFileFunc(func(dir, name string, info fs.FileInfo) bool {
+		visited = append(visited, path(dir, name))
+		return false
+	})
+	expected := []string{
+		path("testdata", "a", "test", "aaa.txt"),
+		path("testdata", "b", "test", "bbb.txt"),
+		path("testdata", "c", "test", "ccc.txt"),
+		path("testdata", "c", "test", "d"),
+	}
+	if len(visited) != len(expected) {
+		t.Fatalf("expected %v, but got %v", expected, visited)
+	}
+	for i := range expected {
+		if visited[i] != expected[i] {
+			t.Errorf("expected %s, but got %s", expected[i], visited[i])
+		}
+	}
+}
+
+func TestAppFindFirstConfigFile(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "a"))
+	os.Setenv("XDG_CONFIG_DIRS", join(path("testdata", "b"), path("testdata", "c")))
+
+	table := []struct {
+		names    []string
+		expected string
+	}{
+		{[]string{"ccc.txt", "bbb.txt"}, path("testdata", "b", "test", "bbb.txt")},
+		{[]string{"bbb.txt", "aaa.txt"}, path("testdata", "a", "test", "aaa.txt")},
+		{[]string{"none.txt", "ccc.txt"}, path("testdata", "c", "test", "ccc.txt")},
+	}
+	for _, tbl := range table {
+		f, err := app.FindFirstConfigFile(tbl.names...)
+		if err != nil {
+			t.Error(err)
+		}
+		if f != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, f)
+		}
+	}
+
+	_, err := app.FindFirstConfigFile("x.toml", "x.json")
+	var nf *NotFoundError
+	if !errors.As(err, &nf) || !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected NotFoundError, but got %v", err)
+	}
+	if !reflect.DeepEqual(nf.Names, []string{"x.toml", "x.json"}) || !reflect.DeepEqual(nf.Dirs, app.ConfigDirs()) {
+		t.Errorf("unexpected candidates %v in %v", nf.Names, nf.Dirs)
+	}
+}
@@ -0,0 +1,105 @@
+package xdgdir
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// FindAllConfigFiles finds all config files that have given name.
+//
+// Found files are returned in precedence order:
+//
+// 1. File in directory that is returned App#ConfigDir.
+// 2. Files in directories that are defined at XDG_CONFIG_DIRS envvar.
+func (a App) FindAllConfigFiles(names ...string) ([]string, error) {
+	return a.FindAllConfigFilesContext(context.Background(), names...)
+}
+
+// FindAllConfigFilesContext is like FindAllConfigFiles but stops searching when ctx is done.
+func (a App) FindAllConfigFilesContext(ctx context.Context, names ...string) ([]string, error) {
+	d, _ := a.ConfigDir()
+	dirs := a.dirsForSearch(d, "XDG_CONFIG_DIRS")
+	return a.findAllFiles(ctx, dirs, names...)
+}
+
+// FindAllDataFiles finds all data files that have given name.
+//
+// Found files are returned in precedence order:
+//
+// 1. File in directory that is returned App#DataDir.
+// 2. Files in directories that are defined at XDG_DATA_DIRS envvar.
+func (a App) FindAllDataFiles(names ...string) ([]string, error) {
+	return a.FindAllDataFilesContext(context.Background(), names...)
+}
+
+// FindAllDataFilesContext is like FindAllDataFiles but stops searching when ctx is done.
+//
+// Search directories are statted in parallel by at most App's concurrency level workers
+// (see WithConcurrency), and results are assembled in precedence order.
+func (a App) FindAllDataFilesContext(ctx context.Context, names ...string) ([]string, error) {
+	d, _ := a.DataDir()
+	dirs := a.dirsForSearch(d, "XDG_DATA_DIRS")
+	return a.findAllFiles(ctx, dirs, names...)
+}
+
+func (a App) concurrency() int {
+	if a.opt().concurrency > 0 {
+		return a.opt().concurrency
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+func (a App) findAllFiles(ctx context.Context, dirs []string, names ...string) ([]string, error) {
+	np := filepath.Join(names...)
+	workers := a.concurrency()
+	if workers > len(dirs) {
+		workers = len(dirs)
+	}
+
+	found := make([]string, len(dirs))
+	idx := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range idx {
+				fp := filepath.Join(dirs[i], np)
+				if _, err := a.fs().Stat(fp); err == nil {
+					found[i] = fp
+				}
+			}
+		}()
+	}
+
+loop:
+	for i, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		select {
+		case idx <- i:
+		case <-ctx.Done():
+			break loop
+		}
+	}
+	close(idx)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, fp := range found {
+		if fp != "" {
+			files = append(files, fp)
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("file %s is %w", np, ErrNotFound)
+	}
+	return files, nil
+}
@@ -0,0 +1,103 @@
+package xdgdir
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppFindAllDataFiles(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_DATA_HOME", path("testdata", "a"))
+	os.Setenv("XDG_DATA_DIRS", join(path("testdata", "b"), path("testdata", "c")))
+
+	files, err := app.FindAllDataFiles("d", "ddd.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{path("testdata", "c", "test", "d", "ddd.txt")}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected %v, but got %v", expected, files)
+	}
+
+	if _, err := app.FindAllDataFiles("zzz.txt"); err == nil {
+		t.Error("should raise error, but not raised")
+	}
+}
+
+func TestAppFindAllDataFilesKeepsPrecedence(t *testing.T) {
+	fsys := statFS{exists: func(name string) bool { return !strings.HasPrefix(name, "d1") }}
+	app := NewApp("test", WithConcurrency(4), WithFileSystem(fsys))
+	os.Setenv("XDG_DATA_HOME", "h")
+	dirs := make([]string, 10)
+	for i := range dirs {
+		dirs[i] = fmt.Sprintf("d%d", i)
+	}
+	os.Setenv("XDG_DATA_DIRS", join(dirs...))
+
+	files, err := app.FindAllDataFiles("x.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{path("h", "test", "x.txt")}
+	for _, d := range dirs {
+		if d != "d1" {
+			expected = append(expected, path(d, "test", "x.txt"))
+		}
+	}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected %v, but got %v", expected, files)
+	}
+}
+
+func TestAppFindAllDataFilesContextCanceled(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_DATA_HOME", path("testdata", "a"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := app.FindAllDataFilesContext(ctx, "aaa.txt"); err != context.Canceled {
+		t.Errorf("expected %v, but got %v", context.Canceled, err)
+	}
+}
+
+func BenchmarkFindAllDataFiles(b *testing.B) {
+	fsys := statFS{delay: time.Millisecond, exists: func(string) bool { return true }}
+	dirs := make([]string, 50)
+	for i := range dirs {
+		dirs[i] = fmt.Sprintf("d%d", i)
+	}
+	os.Setenv("XDG_DATA_HOME", "h")
+	os.Setenv("XDG_DATA_DIRS", join(dirs...))
+
+	for _, n := range []int{1, 8, 32} {
+		app := NewApp("test", WithConcurrency(n), WithFileSystem(fsys))
+		b.Run(fmt.Sprintf("concurrency=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := app.FindAllDataFiles("x.txt"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// statFS is FileSystem whose Stat is slow and reports only files accepted by exists.
+type statFS struct {
+	osFileSystem
+	delay  time.Duration
+	exists func(string) bool
+}
+
+func (s statFS) Stat(name string) (fs.FileInfo, error) {
+	time.Sleep(s.delay)
+	if s.exists(name) {
+		return nil, nil
+	}
+	return nil, fs.ErrNotExist
+}
@@ -0,0 +1,40 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"time"
+)
+
+// ConfiguredMarker is name of marker file that MarkConfigured creates in app's config directory.
+//
+// Content of the file is the time when app was marked as configured in RFC 3339 format.
+const ConfiguredMarker = ".xdgdir-configured"
+
+// IsFirstRun reports whether app's config directory is absent or empty.
+func (a App) IsFirstRun() (bool, error) {
+	dir, err := a.ConfigDir()
+	if err != nil {
+		return false, err
+	}
+
+	entries, err := a.fs().ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return len(entries) == 0, nil
+}
+
+// MarkConfigured creates app's config directory and ConfiguredMarker file in it,
+// so that subsequent IsFirstRun calls return false.
+func (a App) MarkConfigured() error {
+	dir, err := a.ConfigDir()
+	if err != nil {
+		return err
+	}
+	return a.writeFileAtomic(filepath.Join(dir, ConfiguredMarker), []byte(time.Now().Format(time.RFC3339)+"\n"))
+}
@@ -0,0 +1,47 @@
+package xdgdir
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAppIsFirstRun(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if first, err := app.IsFirstRun(); err != nil || !first {
+		t.Errorf("expected first run for absent dir, but got %v (%v)", first, err)
+	}
+
+	dir, _ := app.ConfigDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if first, err := app.IsFirstRun(); err != nil || !first {
+		t.Errorf("expected first run for empty dir, but got %v (%v)", first, err)
+	}
+
+	if err := app.MarkConfigured(); err != nil {
+		t.Fatal(err)
+	}
+	if first, err := app.IsFirstRun(); err != nil || first {
+		t.Errorf("expected not first run after MarkConfigured, but got %v (%v)", first, err)
+	}
+	if _, err := os.Stat(path(dir, ConfiguredMarker)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAppIsFirstRunWithoutHome(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", "")
+	os.Setenv("HOME", "")
+	os.Setenv("USERPROFILE", "")
+
+	if _, err := app.IsFirstRun(); err == nil {
+		t.Error("should raise error, but not raised")
+	}
+	if err := app.MarkConfigured(); err == nil {
+		t.Error("should raise error, but not raised")
+	}
+}
@@ -0,0 +1,48 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows
+// +build darwin dragonfly freebsd linux netbsd openbsd windows
+
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestAppEditConfigFileLockedTimeout(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	fp, _ := app.ConfigFile("config.txt")
+	os.MkdirAll(path(fp, ".."), 0700)
+
+	// Lock from another file descriptor behaves like another process.
+	lf, err := os.OpenFile(fp+".lock", os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lf.Close()
+	if ok, err := tryLockFile(lf); !ok || err != nil {
+		t.Fatalf("failed to lock: %v", err)
+	}
+
+	edited := false
+	err = app.EditConfigFileLocked("config.txt", 50*time.Millisecond, func(cur []byte) ([]byte, error) {
+		edited = true
+		return cur, nil
+	})
+	if !errors.Is(err, ErrLocked) {
+		t.Errorf("expected %v, but got %v", ErrLocked, err)
+	}
+	if edited {
+		t.Error("edit should not be called without lock")
+	}
+
+	unlockFile(lf)
+	err = app.EditConfigFileLocked("config.txt", 50*time.Millisecond, func(cur []byte) ([]byte, error) {
+		return []byte("a"), nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,27 @@
+package xdgdir
+
+// OtherApp returns new app object of another well-known app, for example to read terminal's config from theme manager.
+//
+// This is same as NewApp(name), but makes intent clear at call site.
+func OtherApp(name string) App {
+	return NewApp(name)
+}
+
+// ForeignConfigFile returns file path of config file that has given name in config directory of another app.
+//
+// The path is resolved by same rules and options as App#ConfigFile, except options that are specific to this app,
+// such as WithEnvPrefix, WithNameTransform, tenant of App#Tenant and layout of NewAppFromLayout.
+// Both app and name are validated, and app must be plain directory name, so that it cannot escape config directory.
+func (a App) ForeignConfigFile(app, name string) (string, error) {
+	if err := validateDirName("app name", app); err != nil {
+		return "", err
+	}
+	f := a.withOptions(func(o *options) {
+		o.envPrefix = ""
+		o.layout = nil
+		o.nameTransform = nil
+		o.tenant = nil
+	})
+	f.Name = app
+	return f.ConfigFile(name)
+}
@@ -0,0 +1,59 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAppForeignConfigFile(t *testing.T) {
+	os.Setenv("XDG_CONFIG_HOME", "a")
+	os.Setenv("MYAPP_CONFIG_HOME", "m")
+	app := NewApp("test", WithEnvPrefix("MYAPP"))
+
+	table := []struct {
+		app      string
+		name     string
+		expected string
+		err      bool
+	}{
+		{"term", "config.toml", path("a", "term", "config.toml"), false},
+		{"", "config.toml", "", true},
+		{"term\x00", "config.toml", "", true},
+		{"term", "config\x00", "", true},
+		{"../etc", "passwd", "", true},
+		{"..", "passwd", "", true},
+		{".", "config.toml", "", true},
+		{path("a", "b"), "config.toml", "", true},
+	}
+	for _, tbl := range table {
+		f, err := app.ForeignConfigFile(tbl.app, tbl.name)
+		if tbl.err {
+			if !errors.Is(err, ErrInvalidName) {
+				t.Errorf("should raise error for %q %q, but not raised", tbl.app, tbl.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+		}
+		if f != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, f)
+		}
+	}
+
+	for _, app := range []App{
+		NewApp("test", WithNameTransform(strings.ToUpper)),
+		NewApp("test").Tenant("t1"),
+	} {
+		expected := path("a", "term", "config.toml")
+		if f, err := app.ForeignConfigFile("term", "config.toml"); err != nil || f != expected {
+			t.Errorf("expected %s, but got %s, %v", expected, f, err)
+		}
+	}
+
+	if f, _ := OtherApp("term").ConfigFile("config.toml"); f != path("a", "term", "config.toml") {
+		t.Errorf("expected %s, but got %s", path("a", "term", "config.toml"), f)
+	}
+}
@@ -0,0 +1,37 @@
+package xdgdir
+
+import "strings"
+
+// WithConfigFormats registers extensions of config file formats, such as ".toml", ".yaml" and ".json",
+// in priority order for FindConfigByBasename.
+//
+// Formats are registered per App, so different apps can prefer different formats.
+// Calling this option multiple times appends formats.
+func WithConfigFormats(exts ...string) Option {
+	return func(a *App) {
+		a.opts.configFormats = append(append([]string(nil), a.opt().configFormats...), exts...)
+	}
+}
+
+// FindConfigByBasename finds app's config file that has given basename and one of extensions that are registered
+// by WithConfigFormats, and returns its path and the matched extension, so that caller can pick the right decoder.
+//
+// Directories are searched in same order as FindConfigFile, and in each directory,
+// extensions are tried in registered order before next directory, like FindFirstConfigFile.
+// If no file is found, returns *NotFoundError.
+func (a App) FindConfigByBasename(base string) (path, format string, err error) {
+	names := make([]string, len(a.opt().configFormats))
+	for i, ext := range a.opt().configFormats {
+		names[i] = base + ext
+	}
+	fp, err := a.FindFirstConfigFile(names...)
+	if err != nil {
+		return "", "", err
+	}
+	for _, ext := range a.opt().configFormats {
+		if strings.HasSuffix(fp, base+ext) {
+			return fp, ext, nil
+		}
+	}
+	return fp, "", nil
+}
@@ -0,0 +1,52 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestAppFindConfigByBasename(t *testing.T) {
+	user, system := t.TempDir(), t.TempDir()
+	os.Setenv("XDG_CONFIG_HOME", user)
+	os.Setenv("XDG_CONFIG_DIRS", system)
+	for _, fp := range []string{
+		path(user, "test", "config.json"),
+		path(system, "test", "config.toml"),
+		path(system, "test", "other.yaml"),
+	} {
+		os.MkdirAll(path(fp, ".."), 0700)
+		if err := os.WriteFile(fp, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	table := []struct {
+		formats  []string
+		base     string
+		expected string
+		format   string
+	}{
+		{[]string{".toml", ".yaml", ".json"}, "config", path(user, "test", "config.json"), ".json"},
+		{[]string{".toml", ".yaml"}, "config", path(system, "test", "config.toml"), ".toml"},
+		{[]string{".toml", ".yaml"}, "other", path(system, "test", "other.yaml"), ".yaml"},
+	}
+	for _, tbl := range table {
+		app := NewApp("test", WithConfigFormats(tbl.formats...))
+		fp, format, err := app.FindConfigByBasename(tbl.base)
+		if err != nil {
+			t.Error(err)
+		}
+		if fp != tbl.expected || format != tbl.format {
+			t.Errorf("expected %s %s, but got %s %s", tbl.expected, tbl.format, fp, format)
+		}
+	}
+
+	app := NewApp("test", WithConfigFormats(".toml"), WithConfigFormats(".ini"))
+	if _, _, err := app.FindConfigByBasename("config"); err != nil {
+		t.Error(err)
+	}
+	if _, _, err := app.FindConfigByBasename("none"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected %v, but got %v", ErrNotFound, err)
+	}
+}
@@ -0,0 +1,39 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+)
+
+// ReadConfigFileFS reads app's config file that has given name like fs.ReadFile,
+// but across the whole config search path.
+//
+// 1. Search in directory that is returned App#ConfigDir.
+// 2. Search in directories that are defined at XDG_CONFIG_DIRS envvar.
+//
+// name must be slash-separated path that satisfies fs.ValidPath.
+// If the file is not found, returned error satisfies both errors.Is(err, fs.ErrNotExist) and errors.Is(err, ErrNotFound).
+func (a App) ReadConfigFileFS(name string) ([]byte, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+
+	d, _ := a.ConfigDir()
+	for _, dir := range a.dirsForSearch(d, "XDG_CONFIG_DIRS") {
+		if dir == "" {
+			continue
+		}
+		b, err := readFile(a.fs(), filepath.Join(dir, filepath.FromSlash(name)))
+		if err == nil {
+			return b, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: ErrNotFound}
+}
@@ -0,0 +1,46 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAppReadConfigFileFS(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "a"))
+	os.Setenv("XDG_CONFIG_DIRS", join(path("testdata", "b"), path("testdata", "c")))
+	table := []struct {
+		name    string
+		content string
+	}{
+		{"aaa.txt", "testdata/a/test/aaa.txt"},
+		{"bbb.txt", "testdata/b/test/bbb.txt"},
+		{"d/ddd.txt", "testdata/c/test/d/ddd.txt"},
+	}
+	for _, tbl := range table {
+		b, err := app.ReadConfigFileFS(tbl.name)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if s := strings.TrimSpace(string(b)); s != tbl.content {
+			t.Errorf("expected %s, but got %s", tbl.content, s)
+		}
+	}
+}
+
+func TestAppReadConfigFileFSNotFound(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "a"))
+	os.Setenv("XDG_CONFIG_DIRS", join(path("testdata", "b"), path("testdata", "c")))
+
+	if _, err := app.ReadConfigFileFS("zzz.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist, but got %v", err)
+	}
+	if _, err := app.ReadConfigFileFS("../a/test/aaa.txt"); !errors.Is(err, fs.ErrInvalid) {
+		t.Errorf("expected fs.ErrInvalid, but got %v", err)
+	}
+}
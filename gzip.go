@@ -0,0 +1,81 @@
+package xdgdir
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// OpenDataFileMaybeGzip finds data file that has given name or given name + ".gz", and opens it for reading.
+//
+// Each directory of data search path is searched for name and then name + ".gz", before next directory.
+// If found file ends with ".gz" or starts with gzip magic header, returned reader decompresses its contents.
+// Closing returned reader closes the file too.
+func (a App) OpenDataFileMaybeGzip(names ...string) (io.ReadCloser, error) {
+	np := filepath.Join(names...)
+	if err := ValidateName(np); err != nil {
+		return nil, err
+	}
+
+	d, _ := a.DataDir()
+	for _, dir := range a.dirsForSearch(d, "XDG_DATA_DIRS") {
+		if dir == "" {
+			continue
+		}
+		for _, fp := range []string{filepath.Join(dir, np), filepath.Join(dir, np+".gz")} {
+			f, err := a.fs().Open(fp)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			return maybeGzip(f, strings.HasSuffix(fp, ".gz"))
+		}
+	}
+	return nil, fmt.Errorf("file %s is %w", np, ErrNotFound)
+}
+
+type gzipFile struct {
+	*gzip.Reader
+	f io.Closer
+}
+
+func (g gzipFile) Close() error {
+	err := g.Reader.Close()
+	if cerr := g.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+type bufferedFile struct {
+	*bufio.Reader
+	io.Closer
+}
+
+func maybeGzip(f fs.File, gz bool) (io.ReadCloser, error) {
+	br := bufio.NewReader(f)
+	if !gz {
+		head, _ := br.Peek(len(gzipMagic))
+		gz = bytes.Equal(head, gzipMagic)
+	}
+	if !gz {
+		return bufferedFile{br, f}, nil
+	}
+
+	zr, err := gzip.NewReader(br)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return gzipFile{zr, f}, nil
+}
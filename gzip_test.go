@@ -0,0 +1,69 @@
+package xdgdir
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestAppOpenDataFileMaybeGzip(t *testing.T) {
+	user, system := t.TempDir(), t.TempDir()
+	os.Setenv("XDG_DATA_HOME", user)
+	os.Setenv("XDG_DATA_DIRS", system)
+	app := NewApp("test")
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte("compressed"))
+	zw.Close()
+	for fp, data := range map[string][]byte{
+		path(user, "test", "plain.txt"):       []byte("plain"),
+		path(user, "test", "dict.txt.gz"):     gz.Bytes(),
+		path(system, "test", "magic.bin"):     gz.Bytes(),
+		path(system, "test", "dict.txt"):      []byte("system"),
+		path(system, "test", "broken.txt.gz"): []byte("broken"),
+	} {
+		os.MkdirAll(path(fp, ".."), 0700)
+		if err := os.WriteFile(fp, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	table := []struct {
+		name     string
+		expected string
+		err      bool
+	}{
+		{"plain.txt", "plain", false},
+		{"dict.txt", "compressed", false},
+		{"magic.bin", "compressed", false},
+		{"broken.txt", "", true},
+		{"none.txt", "", true},
+	}
+	for _, tbl := range table {
+		r, err := app.OpenDataFileMaybeGzip(tbl.name)
+		if tbl.err {
+			if err == nil {
+				t.Errorf("should raise error for %s, but not raised", tbl.name)
+				r.Close()
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		b, err := io.ReadAll(r)
+		if err != nil {
+			t.Error(err)
+		}
+		if string(b) != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, b)
+		}
+		if err := r.Close(); err != nil {
+			t.Error(err)
+		}
+	}
+}
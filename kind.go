@@ -0,0 +1,115 @@
+package xdgdir
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind is kind of XDG base directory tree.
+type Kind int
+
+const (
+	// Config is tree of config files (XDG_CONFIG_HOME).
+	Config Kind = iota
+	// Data is tree of data files (XDG_DATA_HOME).
+	Data
+	// Cache is tree of cache files (XDG_CACHE_HOME).
+	Cache
+	// State is tree of state files (XDG_STATE_HOME).
+	State
+	// Runtime is tree of runtime files (XDG_RUNTIME_DIR).
+	Runtime
+	// Bin is user's directory of executables ($HOME/.local/bin), that is shared with other apps.
+	Bin
+)
+
+var kindStrings = [...]string{
+	Config:  "config",
+	Data:    "data",
+	Cache:   "cache",
+	State:   "state",
+	Runtime: "runtime",
+	Bin:     "bin",
+}
+
+// String returns lower case name of kind like "config", that is accepted by ParseKind.
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindStrings) {
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+	return kindStrings[k]
+}
+
+// ParseKind returns Kind that has given name like "cache", for CLI option like "myapp path --kind cache".
+// Name is case insensitive.
+func ParseKind(s string) (Kind, error) {
+	for k, name := range kindStrings {
+		if strings.EqualFold(s, name) {
+			return Kind(k), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown kind %q", s)
+}
+
+// Resolve returns file path of app's file that has given name in the tree of given kind.
+//
+// This dispatches to App#ConfigFile, App#DataFile, App#CacheFile, App#StateFile or App#RuntimeFile.
+// For Bin, returns file path under App#BinDir, because bin directory does not have subdirectory for app.
+func (a App) Resolve(kind Kind, name string) (string, error) {
+	switch kind {
+	case Config:
+		return a.ConfigFile(name)
+	case Data:
+		return a.DataFile(name)
+	case Cache:
+		return a.CacheFile(name)
+	case State:
+		return a.StateFile(name)
+	case Runtime:
+		return a.RuntimeFile(name), nil
+	case Bin:
+		return joinedPath(name, a.BinDir)
+	}
+	return "", fmt.Errorf("unknown kind %d", kind)
+}
+
+// dir returns app's directory of given kind.
+func (a App) dir(kind Kind) (string, error) {
+	switch kind {
+	case Config:
+		return a.ConfigDir()
+	case Data:
+		return a.DataDir()
+	case Cache:
+		return a.CacheDir()
+	case State:
+		return a.StateDir()
+	case Runtime:
+		return a.RuntimeDir(), nil
+	case Bin:
+		return a.BinDir()
+	}
+	return "", fmt.Errorf("unknown kind %d", kind)
+}
+
+// Base returns base directory of given kind that does not contain subdirectory for app,
+// that is the value that apps should use as XDG_*_HOME envvar.
+//
+// This dispatches to ConfigDir, DataDir, CacheDir, StateDir, RuntimeDir or BinDir.
+func Base(kind Kind) (string, error) {
+	switch kind {
+	case Config:
+		return ConfigDir()
+	case Data:
+		return DataDir()
+	case Cache:
+		return CacheDir()
+	case State:
+		return StateDir()
+	case Runtime:
+		return RuntimeDir(), nil
+	case Bin:
+		return BinDir()
+	}
+	return "", fmt.Errorf("unknown kind %d", kind)
+}
@@ -0,0 +1,141 @@
+package xdgdir
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppResolve(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", "a")
+	os.Setenv("XDG_DATA_HOME", "b")
+	os.Setenv("XDG_CACHE_HOME", "c")
+	os.Setenv("XDG_STATE_HOME", "d")
+	os.Setenv("XDG_RUNTIME_DIR", "e")
+	os.Setenv("HOME", "f")
+	name := "file.txt"
+
+	table := []struct {
+		kind Kind
+		f    func(...string) (string, error)
+	}{
+		{Config, app.ConfigFile},
+		{Data, app.DataFile},
+		{Cache, app.CacheFile},
+		{State, app.StateFile},
+		{Runtime, func(names ...string) (string, error) { return app.RuntimeFile(names...), nil }},
+		{Bin, func(names ...string) (string, error) {
+			dir, err := app.BinDir()
+			return filepath.Join(append([]string{dir}, names...)...), err
+		}},
+	}
+	for i, tbl := range table {
+		if tbl.kind != Kind(i) {
+			t.Fatalf("table must cover all kinds in order, %d is missing", i)
+		}
+		expected, _ := tbl.f(name)
+		actual, err := app.Resolve(tbl.kind, name)
+		if err != nil {
+			t.Error(err)
+		}
+		if actual != expected {
+			t.Errorf("expected %s, but got %s", expected, actual)
+		}
+	}
+
+	if _, err := app.Resolve(Kind(len(table)), name); err == nil {
+		t.Errorf("kind %d should be unknown, add it to table", len(table))
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	table := []struct {
+		s        string
+		expected Kind
+		env      string
+		def      string
+	}{
+		{"config", Config, "XDG_CONFIG_HOME", path("home", ".config", "test")},
+		{"data", Data, "XDG_DATA_HOME", path("home", ".local", "share", "test")},
+		{"Cache", Cache, "XDG_CACHE_HOME", path("home", ".cache", "test")},
+		{"STATE", State, "XDG_STATE_HOME", path("home", ".local", "state", "test")},
+		{"runtime", Runtime, "XDG_RUNTIME_DIR", ""},
+		{"bin", Bin, "", path("home", ".local", "bin")},
+	}
+	for i, tbl := range table {
+		if tbl.expected != Kind(i) {
+			t.Fatalf("table must cover all kinds in order, %d is missing", i)
+		}
+		kind, err := ParseKind(tbl.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kind != tbl.expected {
+			t.Errorf("expected %v, but got %v", tbl.expected, kind)
+		}
+		if s, _ := ParseKind(kind.String()); s != kind {
+			t.Errorf("%v should round trip by String, but got %v", kind, s)
+		}
+
+		env := []string{"HOME=home"}
+		if tbl.env != "" {
+			env = append(env, tbl.env+"=x")
+		}
+		dir, err := NewApp("test", WithEnviron(env)).dir(kind)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := tbl.def
+		if tbl.env != "" {
+			expected = path("x", "test")
+		}
+		if dir != expected {
+			t.Errorf("%v: expected %s, but got %s", kind, expected, dir)
+		}
+	}
+
+	if _, err := ParseKind("home"); err == nil {
+		t.Error("should raise error for unknown kind, but not raised")
+	}
+	if s := Kind(len(table)).String(); s != fmt.Sprintf("Kind(%d)", len(table)) {
+		t.Errorf("unexpected string of unknown kind %s", s)
+	}
+}
+
+func TestBase(t *testing.T) {
+	t.Setenv("HOME", "home")
+	t.Setenv("XDG_CONFIG_HOME", "a")
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_CACHE_HOME", "c")
+	t.Setenv("XDG_STATE_HOME", "")
+	t.Setenv("XDG_RUNTIME_DIR", "e")
+
+	table := []struct {
+		kind     Kind
+		expected string
+	}{
+		{Config, "a"},
+		{Data, path("home", ".local", "share")},
+		{Cache, "c"},
+		{State, path("home", ".local", "state")},
+		{Runtime, "e"},
+		{Bin, path("home", ".local", "bin")},
+	}
+	for i, tbl := range table {
+		if tbl.kind != Kind(i) {
+			t.Fatalf("table must cover all kinds in order, %d is missing", i)
+		}
+		dir, err := Base(tbl.kind)
+		if err != nil {
+			t.Error(err)
+		}
+		if dir != tbl.expected {
+			t.Errorf("%v: expected %s, but got %s", tbl.kind, tbl.expected, dir)
+		}
+	}
+	if _, err := Base(Kind(len(table))); err == nil {
+		t.Errorf("kind %d should be unknown, add it to table", len(table))
+	}
+}
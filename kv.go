@@ -0,0 +1,85 @@
+package xdgdir
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// LoadLayeredKV reads config files that have given name in all directories of config search path,
+// and returns merged key=value pairs in them. Values of files with higher precedence win.
+//
+// Files are in simple key=value format like os-release and .env files:
+//
+//	# comment
+//	NAME=value
+//	QUOTED="value with \"escapes\""
+//	LITERAL='value as is'
+//	INLINE=value # comment after whitespace
+//
+// Blank lines and lines that start with # are ignored. If no file is found, returns error that wraps ErrNotFound.
+//
+// If App is derived by App#WithStdinConfig, the reader is read as layer of the highest precedence after files.
+func (a App) LoadLayeredKV(name string) (map[string]string, error) {
+	files, err := a.FindAllConfigFiles(name)
+	if err != nil && (a.opt().stdinConfig == nil || !errors.Is(err, ErrNotFound)) {
+		return nil, err
+	}
+	kv := map[string]string{}
+	for i := len(files) - 1; i >= 0; i-- {
+		b, err := readFile(a.fs(), files[i])
+		if err != nil {
+			return nil, err
+		}
+		if err := parseKV(b, kv); err != nil {
+			return nil, fmt.Errorf("%s: %w", files[i], err)
+		}
+	}
+	if a.opt().stdinConfig != nil {
+		b, err := io.ReadAll(a.opt().stdinConfig)
+		if err != nil {
+			return nil, err
+		}
+		if err := parseKV(b, kv); err != nil {
+			return nil, fmt.Errorf("stdin: %w", err)
+		}
+	}
+	return kv, nil
+}
+
+// parseKV parses key=value lines in b and stores them to kv.
+func parseKV(b []byte, kv map[string]string) error {
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("line %d: expected key=value, but got %q", n, line)
+		}
+		value = strings.TrimSpace(value)
+		switch {
+		case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+			v, err := strconv.Unquote(value)
+			if err != nil {
+				return fmt.Errorf("line %d: invalid quoted value %s", n, value)
+			}
+			value = v
+		case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+			value = value[1 : len(value)-1]
+		default:
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = strings.TrimSpace(value[:i])
+			}
+		}
+		kv[key] = value
+	}
+	return s.Err()
+}
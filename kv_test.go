@@ -0,0 +1,80 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAppLoadLayeredKV(t *testing.T) {
+	dir := t.TempDir()
+	env := []string{"XDG_CONFIG_HOME=" + path(dir, "user"), "XDG_CONFIG_DIRS=" + join(path(dir, "site"), path(dir, "vendor"))}
+	app := NewApp("test", WithEnviron(env))
+	files := map[string]string{
+		"user":   "# user\nNAME=\"my \\\"app\\\"\"\n\nCOLOR = 'blue # not comment'\n",
+		"vendor": "NAME=vendor\nCOLOR=red\nSIZE=10 # default\nEMPTY=\n",
+	}
+	for layer, content := range files {
+		if err := os.MkdirAll(path(dir, layer, "test"), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path(dir, layer, "test", "app.env"), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	kv, err := app.LoadLayeredKV("app.env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"NAME": `my "app"`, "COLOR": "blue # not comment", "SIZE": "10", "EMPTY": ""}
+	if !reflect.DeepEqual(kv, expected) {
+		t.Errorf("expected %v, but got %v", expected, kv)
+	}
+
+	if _, err := app.LoadLayeredKV("missing.env"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, but got %v", err)
+	}
+	if err := os.MkdirAll(path(dir, "site", "test"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path(dir, "site", "test", "app.env"), []byte("NAME\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.LoadLayeredKV("app.env"); err == nil {
+		t.Error("should raise error for line without =, but not raised")
+	}
+}
+
+func TestAppLoadLayeredKVWithStdinConfig(t *testing.T) {
+	dir := t.TempDir()
+	app := NewApp("test", WithEnviron([]string{"XDG_CONFIG_HOME=" + dir, "XDG_CONFIG_DIRS=" + path(dir, "none")}))
+	stdin := app.WithStdinConfig(strings.NewReader("COLOR=green\n"))
+
+	kv, err := stdin.LoadLayeredKV("app.env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[string]string{"COLOR": "green"}; !reflect.DeepEqual(kv, expected) {
+		t.Errorf("expected %v, but got %v", expected, kv)
+	}
+	if _, err := app.LoadLayeredKV("app.env"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("original app should not have stdin layer, but got %v", err)
+	}
+
+	if err := app.WriteConfigFile("app.env", []byte("COLOR=red\nSIZE=10\n")); err != nil {
+		t.Fatal(err)
+	}
+	kv, err = app.WithStdinConfig(strings.NewReader("COLOR=blue\n")).LoadLayeredKV("app.env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[string]string{"COLOR": "blue", "SIZE": "10"}; !reflect.DeepEqual(kv, expected) {
+		t.Errorf("expected %v, but got %v", expected, kv)
+	}
+	if fp, _ := stdin.FindConfigFile("app.env"); fp != path(dir, "test", "app.env") {
+		t.Errorf("stdin should not affect paths, but got %s", fp)
+	}
+}
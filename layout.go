@@ -0,0 +1,56 @@
+package xdgdir
+
+// Layout is resolved directories of app.
+//
+// Layout is marshaled to and unmarshaled from JSON with stable field names,
+// so it can be passed across process boundaries.
+type Layout struct {
+	Name    string `json:"name"`
+	Config  string `json:"config"`
+	Data    string `json:"data"`
+	Cache   string `json:"cache"`
+	State   string `json:"state"`
+	Runtime string `json:"runtime"`
+}
+
+// Layout returns app's resolved directories.
+//
+// Directories that cannot be resolved, for example because home directory is not found, are empty.
+func (a App) Layout() Layout {
+	l := Layout{Name: a.Name, Runtime: a.RuntimeDir()}
+	l.Config, _ = a.ConfigDir()
+	l.Data, _ = a.DataDir()
+	l.Cache, _ = a.CacheDir()
+	l.State, _ = a.StateDir()
+	return l
+}
+
+// NewAppFromLayout returns new app object whose directories are exactly those of given layout.
+//
+// Envvars are not consulted for app's own directories. This decouples resolution, that may be done
+// in privileged context, from use in sandboxed worker.
+// Directories that are empty in the layout, because they were not resolved, return ErrNoHome.
+// Empty runtime directory falls back to temporary directory like App#RuntimeDir without XDG_RUNTIME_DIR,
+// so App#RequireRuntimeDir returns ErrRuntimeDirUnset for it.
+func NewAppFromLayout(l Layout, opts ...Option) App {
+	a := NewApp(l.Name, opts...)
+	a.opts.layout = &l
+	a.opts.env = map[string]string{}
+	return a
+}
+
+func (l *Layout) dir(suffix string) string {
+	switch suffix {
+	case "CONFIG_HOME":
+		return l.Config
+	case "DATA_HOME":
+		return l.Data
+	case "CACHE_HOME":
+		return l.Cache
+	case "STATE_HOME":
+		return l.State
+	case "RUNTIME_DIR":
+		return l.Runtime
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package xdgdir
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppLayout(t *testing.T) {
+	os.Setenv("XDG_CONFIG_HOME", "a")
+	os.Setenv("XDG_DATA_HOME", "b")
+	os.Setenv("XDG_CACHE_HOME", "c")
+	os.Setenv("XDG_STATE_HOME", "d")
+	os.Setenv("XDG_RUNTIME_DIR", "e")
+
+	l := NewApp("test").Layout()
+	expected := Layout{
+		Name:    "test",
+		Config:  path("a", "test"),
+		Data:    path("b", "test"),
+		Cache:   path("c", "test"),
+		State:   path("d", "test"),
+		Runtime: path("e", "test"),
+	}
+	if l != expected {
+		t.Errorf("expected %v, but got %v", expected, l)
+	}
+}
+
+func TestNewAppFromLayout(t *testing.T) {
+	os.Setenv("XDG_CONFIG_HOME", "a")
+	os.Setenv("XDG_DATA_HOME", "b")
+	os.Setenv("XDG_CACHE_HOME", "c")
+	os.Setenv("XDG_STATE_HOME", "d")
+	os.Setenv("XDG_RUNTIME_DIR", "e")
+	b, err := json.Marshal(NewApp("test").Layout())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedJSON := `{"name":"test","config":"a/test","data":"b/test","cache":"c/test","state":"d/test","runtime":"e/test"}`
+	if path("a", "b") == "a/b" && string(b) != expectedJSON {
+		t.Errorf("expected %s, but got %s", expectedJSON, b)
+	}
+
+	os.Setenv("XDG_CONFIG_HOME", "x")
+	os.Setenv("XDG_DATA_HOME", "x")
+	os.Setenv("XDG_CACHE_HOME", "x")
+	os.Setenv("XDG_STATE_HOME", "x")
+	os.Setenv("XDG_RUNTIME_DIR", "x")
+	var l Layout
+	if err := json.Unmarshal(b, &l); err != nil {
+		t.Fatal(err)
+	}
+	app := NewAppFromLayout(l)
+
+	table := []struct {
+		f        func() (string, error)
+		expected string
+	}{
+		{app.ConfigDir, path("a", "test")},
+		{app.DataDir, path("b", "test")},
+		{app.CacheDir, path("c", "test")},
+		{app.StateDir, path("d", "test")},
+		{func() (string, error) { return app.RuntimeDir(), nil }, path("e", "test")},
+		{func() (string, error) { return app.ConfigFile("config.json") }, path("a", "test", "config.json")},
+	}
+	for _, tbl := range table {
+		dir, err := tbl.f()
+		if err != nil {
+			t.Error(err)
+		}
+		if dir != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, dir)
+		}
+	}
+	if app.Layout() != l {
+		t.Errorf("expected %v, but got %v", l, app.Layout())
+	}
+}
+
+func TestNewAppFromLayoutEmpty(t *testing.T) {
+	app := NewAppFromLayout(Layout{Name: "test", Config: "a", Runtime: "e"})
+	if dir, err := app.ConfigDir(); err != nil || dir != "a" {
+		t.Errorf("expected a, but got %s, %v", dir, err)
+	}
+	for _, f := range []func() (string, error){app.DataDir, app.CacheDir, app.StateDir} {
+		if dir, err := f(); !errors.Is(err, ErrNoHome) {
+			t.Errorf("expected ErrNoHome, but got %q, %v", dir, err)
+		}
+	}
+	if _, err := app.DataFile("x"); !errors.Is(err, ErrNoHome) {
+		t.Errorf("expected ErrNoHome, but got %v", err)
+	}
+}
+
+func TestNewAppFromLayoutEmptyRuntime(t *testing.T) {
+	app := NewAppFromLayout(Layout{Name: "test", Config: "a"})
+	dir := app.RuntimeDir()
+	if dir == "" || !filepath.IsAbs(dir) {
+		t.Errorf("expected absolute fallback runtime dir, but got %q", dir)
+	}
+	if fp := app.RuntimeFile("x.sock"); fp != filepath.Join(dir, "x.sock") {
+		t.Errorf("expected %s, but got %s", filepath.Join(dir, "x.sock"), fp)
+	}
+	if _, err := app.RequireRuntimeDir(); !errors.Is(err, ErrRuntimeDirUnset) {
+		t.Errorf("expected ErrRuntimeDirUnset, but got %v", err)
+	}
+	if dir, err := NewAppFromLayout(Layout{Name: "test", Runtime: "e"}).RequireRuntimeDir(); err != nil || dir != "e" {
+		t.Errorf("expected e, but got %q, %v", dir, err)
+	}
+}
@@ -0,0 +1,82 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+)
+
+// ListConfigFiles returns paths of regular files directly in app's config directory, or its subdirectory
+// that has given names. Subdirectories and symlinks are not listed.
+//
+// Files are sorted lexicographically by name, regardless of iteration order of the underlying filesystem.
+// If the directory does not exist, returns empty list.
+// If reading the directory fails partway, returns files that are read so far with the error.
+func (a App) ListConfigFiles(names ...string) ([]string, error) {
+	return a.listFiles(a.ConfigDir, names, false)
+}
+
+// ListDataFiles is like ListConfigFiles, but lists files in app's data directory.
+func (a App) ListDataFiles(names ...string) ([]string, error) {
+	return a.listFiles(a.DataDir, names, false)
+}
+
+// ListConfigFilesByModTime is like ListConfigFiles, but files are sorted by modification time, newest first,
+// for features like recent files. Files that have same modification time are sorted by name.
+func (a App) ListConfigFilesByModTime(names ...string) ([]string, error) {
+	return a.listFiles(a.ConfigDir, names, true)
+}
+
+// ListDataFilesByModTime is like ListDataFiles, but files are sorted by modification time, newest first.
+func (a App) ListDataFilesByModTime(names ...string) ([]string, error) {
+	return a.listFiles(a.DataDir, names, true)
+}
+
+func (a App) listFiles(f func() (string, error), names []string, byModTime bool) ([]string, error) {
+	dir, err := joinedPath(filepath.Join(names...), f)
+	if err != nil {
+		return nil, err
+	}
+	entries, readErr := a.fs().ReadDir(dir)
+	if errors.Is(readErr, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if readErr != nil && len(entries) == 0 {
+		return nil, readErr
+	}
+
+	type file struct {
+		name string
+		info fs.FileInfo
+	}
+	var files []file
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		fi := file{name: e.Name()}
+		if byModTime {
+			var err error
+			if fi.info, err = e.Info(); err != nil {
+				return nil, err
+			}
+		}
+		files = append(files, fi)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if byModTime {
+			ti, tj := files[i].info.ModTime(), files[j].info.ModTime()
+			if !ti.Equal(tj) {
+				return ti.After(tj)
+			}
+		}
+		return files[i].name < files[j].name
+	})
+
+	paths := make([]string, len(files))
+	for i, fi := range files {
+		paths[i] = filepath.Join(dir, fi.name)
+	}
+	return paths, readErr
+}
@@ -0,0 +1,121 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// reversedFS is FileSystem whose ReadDir returns entries in reverse order of name.
+type reversedFS struct {
+	osFileSystem
+}
+
+func (r reversedFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := r.osFileSystem.ReadDir(name)
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, err
+}
+
+func TestAppListConfigFiles(t *testing.T) {
+	dir := t.TempDir()
+	app := NewApp("test", WithEnviron([]string{"XDG_CONFIG_HOME=" + dir}), WithFileSystem(reversedFS{}))
+	if files, err := app.ListConfigFiles(); err != nil || len(files) != 0 {
+		t.Fatalf("missing dir should be empty, but got %v, %v", files, err)
+	}
+
+	base := time.Now().Add(-time.Hour)
+	table := []struct {
+		name string
+		age  time.Duration
+	}{
+		{"b.toml", 2 * time.Minute},
+		{"c.toml", 1 * time.Minute},
+		{"a.toml", 3 * time.Minute},
+		{"d.toml", 1 * time.Minute},
+	}
+	os.MkdirAll(path(dir, "test", "sub"), 0700)
+	for _, tbl := range table {
+		fp := path(dir, "test", tbl.name)
+		if err := os.WriteFile(fp, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+		mtime := base.Add(-tbl.age)
+		if err := os.Chtimes(fp, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := app.ListConfigFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{path(dir, "test", "a.toml"), path(dir, "test", "b.toml"), path(dir, "test", "c.toml"), path(dir, "test", "d.toml")}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected %v, but got %v", expected, files)
+	}
+
+	files, err = app.ListConfigFilesByModTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = []string{path(dir, "test", "c.toml"), path(dir, "test", "d.toml"), path(dir, "test", "b.toml"), path(dir, "test", "a.toml")}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected %v, but got %v", expected, files)
+	}
+
+	if files, err := app.ListConfigFiles("sub"); err != nil || len(files) != 0 {
+		t.Errorf("expected empty subdir, but got %v, %v", files, err)
+	}
+}
+
+// partialFS is FileSystem whose ReadDir fails after reading first entry, like flaky network filesystem.
+type partialFS struct {
+	osFileSystem
+}
+
+var errPartialRead = errors.New("connection reset")
+
+func (p partialFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := p.osFileSystem.ReadDir(name)
+	if err != nil || len(entries) < 2 {
+		return entries, err
+	}
+	return entries[:1], &fs.PathError{Op: "readdirent", Path: name, Err: errPartialRead}
+}
+
+func TestAppPartialReadDir(t *testing.T) {
+	dir := t.TempDir()
+	app := NewApp("test", WithEnviron([]string{"XDG_CONFIG_HOME=" + dir, "XDG_CONFIG_DIRS=" + path(dir, "none")}), WithFileSystem(partialFS{}))
+	for _, name := range []string{"a.toml", "b.toml"} {
+		if err := app.WriteConfigFile(name, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := []string{path(dir, "test", "a.toml")}
+
+	files, err := app.ListConfigFiles()
+	if !errors.Is(err, errPartialRead) {
+		t.Errorf("expected partial read error, but got %v", err)
+	}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected %v, but got %v", expected, files)
+	}
+
+	files = nil
+	err = app.AllConfigFiles(func(p string, layer int, d fs.DirEntry) error {
+		files = append(files, p)
+		return nil
+	})
+	if !errors.Is(err, errPartialRead) {
+		t.Errorf("expected partial read error, but got %v", err)
+	}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected %v, but got %v", expected, files)
+	}
+}
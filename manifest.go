@@ -0,0 +1,104 @@
+package xdgdir
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DataManifestFile is name of manifest file in app's data directory that App#WriteDataManifest writes.
+const DataManifestFile = ".manifest"
+
+// WriteDataManifest walks app's data directory, and writes DataManifestFile atomically,
+// that lists SHA-256 digest and slash separated relative path of each regular file, like sha256sum output:
+//
+//	9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08  themes/dark.css
+//
+// The manifest itself is excluded. Files are hashed by streaming, so large files are not loaded into memory.
+func (a App) WriteDataManifest() error {
+	dir, err := a.DataDir()
+	if err != nil {
+		return err
+	}
+	sums, err := a.hashTree(dir)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(sums))
+	for name := range sums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b bytes.Buffer
+	for _, name := range names {
+		fmt.Fprintf(&b, "%x  %s\n", sums[name], name)
+	}
+	return a.writeFileAtomic(filepath.Join(dir, DataManifestFile), b.Bytes())
+}
+
+// VerifyDataManifest recomputes digests of files that are listed in DataManifestFile, and returns
+// slash separated relative paths of files that are missing or altered, sorted by path.
+// Files that are added after the manifest is written are not reported.
+//
+// If the manifest does not exist, returns error that wraps ErrNotFound.
+func (a App) VerifyDataManifest() ([]string, error) {
+	dir, err := a.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	b, err := readFile(a.fs(), filepath.Join(dir, DataManifestFile))
+	if err != nil {
+		return nil, wrapNotExist(err)
+	}
+
+	var bad []string
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; s.Scan(); n++ {
+		sum, name, ok := strings.Cut(s.Text(), "  ")
+		want, err := hex.DecodeString(sum)
+		if !ok || err != nil || name == "" {
+			return nil, fmt.Errorf("%s: line %d is malformed", DataManifestFile, n)
+		}
+		got, err := hashFile(a.fs().Open, filepath.Join(dir, filepath.FromSlash(name)))
+		if errors.Is(err, fs.ErrNotExist) || err == nil && !bytes.Equal(got, want) {
+			bad = append(bad, name)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	sort.Strings(bad)
+	return bad, nil
+}
+
+// hashTree returns SHA-256 digests of regular files under dir by slash separated relative path,
+// except DataManifestFile directly in dir.
+func (a App) hashTree(dir string) (map[string][]byte, error) {
+	sums := map[string][]byte{}
+	err := a.walkFiles(dir, 0, func(fp string, _ int, _ fs.DirEntry) error {
+		rel, err := filepath.Rel(dir, fp)
+		if err != nil {
+			return err
+		}
+		if rel == DataManifestFile {
+			return nil
+		}
+		sum, err := hashFile(a.fs().Open, fp)
+		sums[filepath.ToSlash(rel)] = sum
+		return err
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return sums, nil
+	}
+	return sums, err
+}
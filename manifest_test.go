@@ -0,0 +1,66 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAppDataManifest(t *testing.T) {
+	dir := t.TempDir()
+	app := NewApp("test", WithEnviron([]string{"XDG_DATA_HOME=" + dir}))
+	if _, err := app.VerifyDataManifest(); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, but got %v", err)
+	}
+
+	files := map[string]string{"a.txt": "test", "themes/dark.css": "dark", "themes/light.css": "light"}
+	for name, content := range files {
+		if err := app.WriteDataFile(name, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := app.WriteDataManifest(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(path(dir, "test", DataManifestFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if expected := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08  a.txt"; len(lines) != 3 || lines[0] != expected {
+		t.Errorf("expected first line %s, but got %q", expected, lines)
+	}
+	// Rewriting manifest does not include itself.
+	if err := app.WriteDataManifest(); err != nil {
+		t.Fatal(err)
+	}
+	if bad, err := app.VerifyDataManifest(); err != nil || len(bad) != 0 {
+		t.Errorf("expected no bad files, but got %v, %v", bad, err)
+	}
+
+	if err := app.WriteDataFile("themes/dark.css", []byte("tampered")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(path(dir, "test", "a.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.WriteDataFile("new.txt", nil); err != nil {
+		t.Fatal(err)
+	}
+	bad, err := app.VerifyDataManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"a.txt", "themes/dark.css"}; !reflect.DeepEqual(bad, expected) {
+		t.Errorf("expected %v, but got %v", expected, bad)
+	}
+
+	if err := app.WriteDataFile(DataManifestFile, []byte("broken\n")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.VerifyDataManifest(); err == nil {
+		t.Error("should raise error for malformed manifest, but not raised")
+	}
+}
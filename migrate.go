@@ -0,0 +1,80 @@
+package xdgdir
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ConfigVersionFile is name of file in app's config directory that stores version of app's config format.
+const ConfigVersionFile = ".schema-version"
+
+// ConfigVersion returns version of app's config format that is stored by SetConfigVersion.
+//
+// If the version file does not exist, returns 0.
+func (a App) ConfigVersion() (int, error) {
+	fp, err := a.ConfigFile(ConfigVersionFile)
+	if err != nil {
+		return 0, err
+	}
+	b, err := readFile(a.fs(), fp)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid config version in %s: %w", fp, err)
+	}
+	return v, nil
+}
+
+// SetConfigVersion stores version of app's config format in ConfigVersionFile atomically.
+func (a App) SetConfigVersion(version int) error {
+	fp, err := a.ConfigFile(ConfigVersionFile)
+	if err != nil {
+		return err
+	}
+	return a.writeFileAtomic(fp, []byte(strconv.Itoa(version)+"\n"))
+}
+
+// MigrateConfig upgrades app's config to current version by running migrations in order.
+//
+// migrations[n] upgrades config from version n-1 to n. Migrations from stored version + 1 to current are run,
+// and stored version is updated after each migration, so failed migration is retried on next call.
+// Returns error if a migration is missing, or if stored version is newer than current.
+func (a App) MigrateConfig(current int, migrations map[int]func(App) error) error {
+	fp, err := a.ConfigFile(ConfigVersionFile)
+	if err != nil {
+		return err
+	}
+	mu := lockPath(filepath.Clean(fp))
+	mu.Lock()
+	defer mu.Unlock()
+
+	stored, err := a.ConfigVersion()
+	if err != nil {
+		return err
+	}
+	if stored > current {
+		return fmt.Errorf("config version %d is newer than %d", stored, current)
+	}
+	for v := stored + 1; v <= current; v++ {
+		m, ok := migrations[v]
+		if !ok {
+			return fmt.Errorf("migration to config version %d is not found", v)
+		}
+		if err := m(a); err != nil {
+			return fmt.Errorf("migration to config version %d: %w", v, err)
+		}
+		if err := a.SetConfigVersion(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestAppConfigVersion(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if v, err := app.ConfigVersion(); err != nil || v != 0 {
+		t.Errorf("expected 0, but got %d, %v", v, err)
+	}
+	if err := app.SetConfigVersion(3); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := app.ConfigVersion(); err != nil || v != 3 {
+		t.Errorf("expected 3, but got %d, %v", v, err)
+	}
+}
+
+func TestAppMigrateConfig(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var ran []int
+	fail := errors.New("fail")
+	migrations := map[int]func(App) error{
+		1: func(App) error { ran = append(ran, 1); return nil },
+		2: func(App) error { ran = append(ran, 2); return nil },
+		3: func(App) error { ran = append(ran, 3); return fail },
+	}
+
+	if err := app.MigrateConfig(2, migrations); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.MigrateConfig(3, migrations); !errors.Is(err, fail) {
+		t.Errorf("expected %v, but got %v", fail, err)
+	}
+	if !reflect.DeepEqual(ran, []int{1, 2, 3}) {
+		t.Errorf("expected migrations 1, 2, 3, but got %v", ran)
+	}
+	if v, _ := app.ConfigVersion(); v != 2 {
+		t.Errorf("expected version 2 after failed migration, but got %d", v)
+	}
+
+	table := []struct {
+		current int
+		err     bool
+	}{
+		{2, false},
+		{4, true},
+		{1, true},
+	}
+	for _, tbl := range table {
+		err := app.MigrateConfig(tbl.current, map[int]func(App) error{3: func(App) error { return nil }})
+		if tbl.err && err == nil {
+			t.Errorf("should raise error for %d, but not raised", tbl.current)
+		}
+		if !tbl.err && err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package xdgdir
+
+import "os"
+
+// ConfigFileMode returns permission bits of app's config file that has given name in directory that is returned App#ConfigDir.
+//
+// If the file does not exist, returned error satisfies both errors.Is(err, fs.ErrNotExist) and errors.Is(err, ErrNotFound).
+func (a App) ConfigFileMode(name string) (os.FileMode, error) {
+	fp, err := a.ConfigFile(name)
+	if err != nil {
+		return 0, err
+	}
+	fi, err := a.fs().Stat(fp)
+	if err != nil {
+		return 0, wrapNotExist(err)
+	}
+	return fi.Mode().Perm(), nil
+}
+
+// SecureConfigFile changes permission of app's config file that has given name to 0600,
+// so that files holding secrets are not readable by group and others.
+//
+// On Windows, this does nothing, because permission bits do not control access there; use ACLs instead.
+// On other platforms, if FileSystem of WithFileSystem option does not support Chmod, returns error,
+// so that caller does not assume the file is secured.
+// If the file does not exist, returned error satisfies both errors.Is(err, fs.ErrNotExist) and errors.Is(err, ErrNotFound).
+func (a App) SecureConfigFile(name string) error {
+	fp, err := a.ConfigFile(name)
+	if err != nil {
+		return err
+	}
+	if _, err := a.fs().Stat(fp); err != nil {
+		return wrapNotExist(err)
+	}
+	if a.goos() == "windows" {
+		return nil
+	}
+	return chmod(a.fs(), fp, 0600)
+}
@@ -0,0 +1,55 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+func TestAppSecureConfigFile(t *testing.T) {
+	app := NewApp("test", WithGOOS("linux"))
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	fp, _ := app.ConfigFile("token")
+	os.MkdirAll(path(fp, ".."), 0700)
+	if err := os.WriteFile(fp, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(fp, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if mode, err := app.ConfigFileMode("token"); err != nil || mode != 0644 {
+		t.Errorf("expected 644, but got %o, %v", mode, err)
+	}
+	if err := NewApp("test", WithGOOS("windows")).SecureConfigFile("token"); err != nil {
+		t.Error(err)
+	}
+	if mode, _ := app.ConfigFileMode("token"); mode != 0644 {
+		t.Errorf("mode should not be changed on windows, but got %o", mode)
+	}
+	if err := app.SecureConfigFile("token"); err != nil {
+		t.Fatal(err)
+	}
+	if mode, err := app.ConfigFileMode("token"); err != nil || mode != 0600 {
+		t.Errorf("expected 600, but got %o, %v", mode, err)
+	}
+
+	if _, err := app.ConfigFileMode("none"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected %v, but got %v", fs.ErrNotExist, err)
+	}
+	if err := app.SecureConfigFile("none"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected %v, but got %v", fs.ErrNotExist, err)
+	}
+
+	if err := os.Chmod(fp, 0644); err != nil {
+		t.Fatal(err)
+	}
+	noChmod := NewApp("test", WithGOOS("linux"), WithFileSystem(statOnlyFS{osFileSystem{}}))
+	if err := noChmod.SecureConfigFile("token"); !errors.Is(err, errUnsupported) {
+		t.Errorf("expected unsupported error without Chmod, but got %v", err)
+	}
+	if mode, _ := app.ConfigFileMode("token"); mode != 0644 {
+		t.Errorf("mode should not be changed without Chmod, but got %o", mode)
+	}
+}
@@ -0,0 +1,57 @@
+package xdgdir
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// MaxNameLen is the maximum length in bytes of each path component, that is the limit of many filesystems
+// such as ext4, xfs, btrfs and APFS.
+const MaxNameLen = 255
+
+// ValidateName checks that given app name or file name can be used as path.
+//
+// Names that contain NUL or other control characters are rejected with error that wraps ErrInvalidName.
+// Names that have component longer than MaxNameLen bytes are rejected with *NameTooLongError.
+func ValidateName(name string) error {
+	for _, r := range name {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("%w: %q contains control character", ErrInvalidName, name)
+		}
+	}
+	if len(name) <= MaxNameLen {
+		return nil
+	}
+	for _, c := range strings.FieldsFunc(name, func(r rune) bool { return r == '/' || r == os.PathSeparator }) {
+		if len(c) > MaxNameLen {
+			return &NameTooLongError{Name: c, Max: MaxNameLen}
+		}
+	}
+	return nil
+}
+
+// checkPathLen returns *NameTooLongError if last component of path exceeds MaxNameLen,
+// or path exceeds PATH_MAX of the platform. This is best-effort check that is done before syscalls.
+// PATH_MAX is not checked on Windows and others whose Go runtime handles long paths, or whose limit is unknown.
+func (a App) checkPathLen(path string) error {
+	if base := filepath.Base(path); len(base) > MaxNameLen {
+		return &NameTooLongError{Name: base, Max: MaxNameLen}
+	}
+	var max int
+	switch a.goos() {
+	case "darwin", "ios":
+		max = 1024
+	case "linux", "android", "dragonfly", "freebsd", "netbsd", "openbsd", "solaris", "illumos", "aix":
+		max = 4096
+	default:
+		return nil
+	}
+	// PATH_MAX includes terminating NUL.
+	if len(path) >= max {
+		return &NameTooLongError{Name: path, Max: max - 1}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateName(t *testing.T) {
+	table := []struct {
+		name string
+		err  bool
+	}{
+		{"test", false},
+		{"my app", false},
+		{"アプリ", false},
+		{"te\x00st", true},
+		{"te\nst", true},
+		{"\ttest", true},
+		{"test\x7f", true},
+	}
+	for _, tbl := range table {
+		err := ValidateName(tbl.name)
+		if tbl.err {
+			if !errors.Is(err, ErrInvalidName) {
+				t.Errorf("expected ErrInvalidName for %q, but got %v", tbl.name, err)
+			}
+		} else if err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAppWithInvalidName(t *testing.T) {
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "a"))
+	os.Setenv("XDG_DATA_HOME", "x")
+	os.Setenv("XDG_CACHE_HOME", "x")
+
+	for _, name := range []string{"te\x00st", "te\nst"} {
+		app := NewApp(name)
+		for _, f := range []func() (string, error){app.ConfigDir, app.DataDir, app.CacheDir} {
+			if _, err := f(); !errors.Is(err, ErrInvalidName) {
+				t.Errorf("expected ErrInvalidName for %q, but got %v", name, err)
+			}
+		}
+	}
+}
+
+func TestAppFileWithInvalidName(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "a"))
+	os.Setenv("XDG_CONFIG_DIRS", "")
+
+	for _, name := range []string{"aaa.txt\x00", "aaa\n.txt"} {
+		if _, err := app.ConfigFile(name); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("expected ErrInvalidName for %q, but got %v", name, err)
+		}
+		if _, err := app.ConfigFile("sub", name); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("expected ErrInvalidName for %q, but got %v", name, err)
+		}
+		if _, err := app.FindConfigFile(name); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("expected ErrInvalidName for %q, but got %v", name, err)
+		}
+		if _, err := app.ReadConfigFileFS(name); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("expected ErrInvalidName for %q, but got %v", name, err)
+		}
+	}
+}
+
+func TestAppWithDotName(t *testing.T) {
+	os.Setenv("XDG_CONFIG_HOME", "x")
+	os.Setenv("XDG_DATA_HOME", "x")
+	table := []string{".", "..", "a/..", "../a", "a/./b", "a//b", "a/", "/a"}
+	for _, name := range table {
+		app := NewApp(name)
+		for _, f := range []func() (string, error){app.ConfigDir, app.DataDir} {
+			if dir, err := f(); !errors.Is(err, ErrInvalidName) {
+				t.Errorf("%q: expected ErrInvalidName, but got %q, %v", name, dir, err)
+			}
+		}
+		if err := app.Validate(); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("%q: expected ErrInvalidName from Validate, but got %v", name, err)
+		}
+	}
+	if dir, err := NewApp(path("vendor", "app")).DataDir(); err != nil || dir != path("x", "vendor", "app") {
+		t.Errorf("nested name should be allowed, but got %q, %v", dir, err)
+	}
+}
+
+func TestAppWithEmptyName(t *testing.T) {
+	app := NewApp("")
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "a"))
+	os.Setenv("XDG_CONFIG_DIRS", path("testdata", "b", "test"))
+	os.Setenv("XDG_DATA_HOME", "x")
+	os.Setenv("XDG_CACHE_HOME", "x")
+	os.Setenv("XDG_STATE_HOME", "x")
+
+	for _, f := range []func() (string, error){app.ConfigDir, app.DataDir, app.CacheDir, app.StateDir} {
+		if _, err := f(); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("expected ErrInvalidName, but got %v", err)
+		}
+	}
+	if _, err := app.ConfigFile("aaa.txt"); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName, but got %v", err)
+	}
+	if _, err := app.FindConfigFile("bbb.txt"); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName, but got %v", err)
+	}
+	if _, err := app.ReadConfigFileFS("bbb.txt"); err == nil {
+		t.Error("system dirs should not be searched without app name")
+	}
+	if dirs := app.ConfigDirs(); len(dirs) != 0 {
+		t.Errorf("expected no dirs, but got %v", dirs)
+	}
+	if err := (App{}).WriteConfigFile("x.txt", nil); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName for zero value, but got %v", err)
+	}
+}
+
+func TestAppWithLongName(t *testing.T) {
+	dir := t.TempDir()
+	long := strings.Repeat("a", 300)
+	env := []string{"XDG_CONFIG_HOME=" + dir, "XDG_DATA_HOME=" + dir}
+
+	var tooLong *NameTooLongError
+	if err := ValidateName(long); !errors.As(err, &tooLong) || tooLong.Max != MaxNameLen {
+		t.Errorf("expected NameTooLongError, but got %v", err)
+	}
+	if err := ValidateName(path("sub", long)); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName, but got %v", err)
+	}
+	if err := ValidateName(strings.Repeat(path("a", "b"), 100)); err != nil {
+		t.Errorf("long path of short components should be valid, but got %v", err)
+	}
+
+	app := NewApp(long, WithEnviron(env))
+	if _, err := app.EnsureConfigDir(); !errors.As(err, &tooLong) {
+		t.Errorf("expected NameTooLongError, but got %v", err)
+	}
+	app = NewApp("test", WithEnviron(env))
+	if err := app.WriteConfigFile(long, nil); !errors.As(err, &tooLong) {
+		t.Errorf("expected NameTooLongError, but got %v", err)
+	}
+	// Name fits, but temporary file for atomic write does not.
+	if err := app.WriteConfigFile(strings.Repeat("a", 250), nil); !errors.As(err, &tooLong) {
+		t.Errorf("expected NameTooLongError, but got %v", err)
+	}
+
+	deep := strings.Repeat(strings.Repeat("d", 200)+string(filepath.Separator), 25)
+	app = NewApp("test", WithEnviron(append(env, "XDG_CACHE_HOME="+path(dir, deep))), WithGOOS("linux"))
+	if _, err := app.EnsureCacheDir(); !errors.As(err, &tooLong) {
+		t.Errorf("expected NameTooLongError, but got %v", err)
+	}
+	if _, err := app.WriteCacheFile("x", nil); !errors.As(err, &tooLong) {
+		t.Errorf("expected NameTooLongError, but got %v", err)
+	}
+}
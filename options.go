@@ -0,0 +1,412 @@
+package xdgdir
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Option configures App that is created by NewApp.
+type Option func(*App)
+
+type options struct {
+	configFallback string
+	dataFallback   string
+	cacheFallback  string
+	concurrency    int
+	listSeparator  byte
+
+	cacheFallbackToTemp bool
+	cacheSharding       int
+	fastCacheWrites     bool
+	cacheNamespace      string
+	lazyCreate          bool
+
+	env       map[string]string
+	envPrefix string
+	uid       string
+	layout    *Layout
+	hostPaths bool
+
+	fileSystem     FileSystem
+	enforceDirMode bool
+	logf           func(format string, v ...interface{})
+	profile        string
+	goos           string
+	retryAttempts  int
+	retryBackoff   time.Duration
+
+	noFilesystemAccess bool
+
+	extraDirs        map[string][]string
+	prependExtraDirs bool
+
+	nameTransform func(string) string
+	canonicalFrom string
+	canonicalName string
+
+	rejectSymlinkedSearchDirs bool
+	tenant                    *string
+	backupDir                 string
+	backupRequired            bool
+	optionErr                 error
+	stdinConfig               io.Reader
+	cacheAccessTracking       bool
+	watchInterval             time.Duration
+	watchDepth                int
+	watchDepthLimited         bool
+
+	userOnly               bool
+	configFormats          []string
+	workingDir             string
+	executableConfigDir    bool
+	executableConfigSubdir string
+}
+
+// WithConfigFallback replaces ".config" segment that is used when XDG_CONFIG_HOME is not defined.
+//
+// rel is relative to home directory, for example ".myconfig" or "etc/config".
+func WithConfigFallback(rel string) Option {
+	return func(a *App) {
+		a.opts.configFallback = rel
+	}
+}
+
+// WithDataFallback replaces ".local/share" segment that is used when XDG_DATA_HOME is not defined.
+//
+// rel is relative to home directory, for example ".mydata" or "var/lib".
+func WithDataFallback(rel string) Option {
+	return func(a *App) {
+		a.opts.dataFallback = rel
+	}
+}
+
+// WithCacheFallback replaces ".cache" segment that is used when XDG_CACHE_HOME is not defined.
+//
+// rel is relative to home directory, for example ".mycache" or "var/cache".
+func WithCacheFallback(rel string) Option {
+	return func(a *App) {
+		a.opts.cacheFallback = rel
+	}
+}
+
+// WithConcurrency sets how many search directories are statted in parallel by FindAll* functions.
+//
+// Default is runtime.GOMAXPROCS(0).
+func WithConcurrency(n int) Option {
+	return func(a *App) {
+		a.opts.concurrency = n
+	}
+}
+
+// WithPathListSeparator sets separator that splits XDG_*_DIRS envvars.
+//
+// Default is os.PathListSeparator. This is mainly for testing Windows semantics on other hosts and vice versa.
+func WithPathListSeparator(sep byte) Option {
+	return func(a *App) {
+		a.opts.listSeparator = sep
+	}
+}
+
+// WithCacheFallbackToTemp makes cache write helpers retry under temporary directory
+// when write to app's cache directory fails because disk is full or permission is denied.
+//
+// This weakens persistence of cache data, because temporary directory may be cleaned by system,
+// but improves resilience for non-critical cache data.
+func WithCacheFallbackToTemp() Option {
+	return func(a *App) {
+		a.opts.cacheFallbackToTemp = true
+	}
+}
+
+// WithLazyCreate makes Ensure methods resolve directories without creating them.
+//
+// Directories are created only by write methods at the moment a file is written,
+// so no empty directories are left when nothing is ever written.
+func WithLazyCreate() Option {
+	return func(a *App) {
+		a.opts.lazyCreate = true
+	}
+}
+
+// WithEnviron makes App resolve directories by given environment instead of the process environment.
+//
+// env is slice of "KEY=VALUE" strings like os.Environ returns.
+// Malformed entries that do not contain "=" are ignored, and later entry wins for duplicated keys.
+// Envvars that are not contained in env are treated as undefined.
+func WithEnviron(env []string) Option {
+	return func(a *App) {
+		m := make(map[string]string, len(env))
+		for _, kv := range env {
+			i := strings.IndexByte(kv, '=')
+			if i <= 0 {
+				continue
+			}
+			m[kv[:i]] = kv[i+1:]
+		}
+		a.opts.env = m
+	}
+}
+
+// WithSyntheticUser makes App resolve directories as if it runs as user whose home directory is home
+// and whose XDG envvars are xdg, for integration tests that exercise envvar based resolution without impersonation.
+//
+// This is same as WithEnviron with HOME, USERPROFILE and $home on Plan 9 set to home, and with given XDG envvars,
+// like {"XDG_CONFIG_HOME": "/tmp/u/conf"}. Other envvars of the process are treated as undefined.
+// home must be absolute, and keys of xdg must start with "XDG_".
+// Otherwise methods that return error fail with error that wraps ErrInvalidName.
+func WithSyntheticUser(home string, xdg map[string]string) Option {
+	return func(a *App) {
+		m := make(map[string]string, len(xdg)+3)
+		for k, v := range xdg {
+			if !strings.HasPrefix(k, "XDG_") {
+				a.opts.optionErr = fmt.Errorf("%w: synthetic user envvar %s is not XDG envvar", ErrInvalidName, k)
+			}
+			m[k] = v
+		}
+		if !filepath.IsAbs(home) {
+			a.opts.optionErr = fmt.Errorf("%w: synthetic user home %q is not absolute", ErrInvalidName, home)
+		}
+		for _, env := range []string{"HOME", "USERPROFILE", "home"} {
+			m[env] = home
+		}
+		a.opts.env = m
+	}
+}
+
+// WithEnvPrefix makes App check app specific envvars that have given prefix before XDG envvars.
+//
+// With prefix "MYAPP", directories are resolved in following order:
+//
+// 1. If MYAPP_CONFIG_HOME envvar is defined, it is used as app's config directory as is (without AppName).
+// 2. If XDG_CONFIG_HOME envvar is defined, returns $XDG_CONFIG_HOME/{{AppName}}.
+// 3. Fallback to HOME or USERPROFILE.
+//
+// Likewise MYAPP_DATA_HOME, MYAPP_CACHE_HOME, MYAPP_STATE_HOME and MYAPP_RUNTIME_DIR precede their XDG envvars.
+func WithEnvPrefix(prefix string) Option {
+	return func(a *App) {
+		a.opts.envPrefix = prefix
+	}
+}
+
+// WithCacheSharding makes CacheFileForKey place files in given levels of two-character subdirectories,
+// to avoid huge flat directories. Negative levels are same as 0.
+func WithCacheSharding(levels int) Option {
+	return func(a *App) {
+		a.opts.cacheSharding = levels
+	}
+}
+
+// WithCacheNamespace makes app's cache files placed in namespace subdirectory of app's cache directory,
+// such as {{CacheDir}}/v2/{{names}} for namespace "v2" that is app's version.
+//
+// This invalidates caches of other versions after app is upgraded, and PruneOtherCacheNamespaces reclaims their space.
+// CacheDir itself, and config and data files are intentionally unaffected.
+func WithCacheNamespace(ns string) Option {
+	return func(a *App) {
+		a.opts.cacheNamespace = ns
+	}
+}
+
+// WithFastCacheWrites makes WriteCacheFile write cache files directly, without temporary file, fsync and rename.
+//
+// This makes writing many small cache files much faster, but trades durability for speed:
+// a crash may leave cache file empty or truncated, and concurrent readers may see partially written file.
+// Use this only if app can detect broken cache files and regenerate them.
+// Config and data files are always written atomically and durably.
+func WithFastCacheWrites() Option {
+	return func(a *App) {
+		a.opts.fastCacheWrites = true
+	}
+}
+
+// WithNoFilesystemAccess guarantees that App never touches filesystem, for tools that compute paths
+// for another machine or container, such as sandbox policy generators.
+//
+// Methods that return paths, such as ConfigDir, ConfigFile, ConfigDirs, Layout and PrintPaths, are pure anyway,
+// and this option ensures that they stay so. Other methods that read or write files, including
+// those that bypass WithFileSystem option, fail with error that wraps ErrReadOnly,
+// and find methods report that files are not found. ConfigSearchPath and DataSearchPath report
+// all directories as not existing.
+func WithNoFilesystemAccess() Option {
+	return func(a *App) {
+		a.opts.noFilesystemAccess = true
+	}
+}
+
+// WithFileSystem makes App access files through given filesystem instead of the OS filesystem.
+//
+// This is mainly for injecting in-memory filesystem in tests.
+func WithFileSystem(fsys FileSystem) Option {
+	return func(a *App) {
+		a.opts.fileSystem = fsys
+	}
+}
+
+// WithEnforceDirMode makes Ensure methods tighten permission of existing directory to 0700
+// if it is looser, such as 0755. The change is reported to the hook that is set by WithLogf.
+//
+// Without this option, permission of existing directory is left as is,
+// because users may intentionally grant group access.
+func WithEnforceDirMode() Option {
+	return func(a *App) {
+		a.opts.enforceDirMode = true
+	}
+}
+
+// WithLogf sets hook that App reports notable events to, such as permission changes.
+//
+// logf has same signature as log.Printf and testing.T#Logf.
+func WithLogf(logf func(format string, v ...interface{})) Option {
+	return func(a *App) {
+		a.opts.logf = logf
+	}
+}
+
+// WithProfile sets app's profile, whose config directory is returned by ProfileConfigDir.
+//
+// profile must be plain directory name that does not start with ".".
+func WithProfile(profile string) Option {
+	return func(a *App) {
+		a.opts.profile = profile
+	}
+}
+
+// WithGOOS makes App behave as if it runs on given GOOS instead of runtime.GOOS.
+//
+// This is for testing platform specific logic, such as Windows fallbacks, on any host.
+func WithGOOS(goos string) Option {
+	return func(a *App) {
+		a.opts.goos = goos
+	}
+}
+
+// WithExtraConfigDirs adds given directories to config search path that find and read methods use.
+//
+// Directories are used as is, without AppName. They are appended after XDG_CONFIG_DIRS,
+// or prepended before app's config directory with WithPrependExtraDirs option.
+// Relative directories and directories that are already in the search path are ignored.
+func WithExtraConfigDirs(dirs ...string) Option {
+	return func(a *App) {
+		a.addExtraDirs("XDG_CONFIG_DIRS", dirs)
+	}
+}
+
+// WithExtraDataDirs adds given directories to data search path that find and read methods use.
+//
+// Directories are used as is, without AppName. They are appended after XDG_DATA_DIRS,
+// or prepended before app's data directory with WithPrependExtraDirs option.
+// Relative directories and directories that are already in the search path are ignored.
+func WithExtraDataDirs(dirs ...string) Option {
+	return func(a *App) {
+		a.addExtraDirs("XDG_DATA_DIRS", dirs)
+	}
+}
+
+// WithWorkingDir makes App anchor relative directories to dir instead of the process working directory.
+//
+// XDG envvars, HOME and TMPDIR should be absolute, but relative values are used as is by default,
+// so that resolved paths depend on the process working directory. With this option,
+// such relative directories are joined to dir, which makes resolution deterministic and testable.
+func WithWorkingDir(dir string) Option {
+	return func(a *App) {
+		a.opts.workingDir = dir
+	}
+}
+
+// WithUserOnly makes App search only app's user directories, such as App#ConfigDir and App#DataDir,
+// for apps that must not honor files in system directories that may be controlled by others.
+//
+// Directories of XDG_CONFIG_DIRS and XDG_DATA_DIRS envvars, their defaults and extra directories are ignored
+// by all find and read methods, and ConfigDirs and DataDirs return only app's user directory.
+func WithUserOnly() Option {
+	return func(a *App) {
+		a.opts.userOnly = true
+	}
+}
+
+// WithRejectSymlinkedSearchDirs makes find and read methods skip directories in search path that are symlinks,
+// so that symlinked entry of XDG_CONFIG_DIRS cannot redirect them to location controlled by others.
+//
+// Both app's directory and the base directory that contains it are checked by lstat on the OS filesystem.
+// Skipped directories are logged by WithLogf option. Note that this also skips symlinked user directory
+// such as ~/.config that is managed by dotfiles.
+func WithRejectSymlinkedSearchDirs() Option {
+	return func(a *App) {
+		a.opts.rejectSymlinkedSearchDirs = true
+	}
+}
+
+// WithBackupDir makes every successful write of file in app's config or data directory also copy the file
+// to dir, like dir/config/{{relative path}} and dir/data/{{relative path}}, so that dir has continuously updated backup.
+//
+// The backup is copied after the write is committed, so it never has partially written file.
+// Failure of the backup is logged by WithLogf option, and does not fail the write unless WithBackupRequired is set.
+func WithBackupDir(dir string) Option {
+	return func(a *App) {
+		a.opts.backupDir = dir
+	}
+}
+
+// WithBackupRequired makes failure of backup of WithBackupDir option fail the write, though the file itself is written.
+func WithBackupRequired() Option {
+	return func(a *App) {
+		a.opts.backupRequired = true
+	}
+}
+
+// WithWatchInterval sets polling interval of App#WatchConfigDir, which is 1 second by default.
+func WithWatchInterval(d time.Duration) Option {
+	return func(a *App) {
+		a.opts.watchInterval = d
+	}
+}
+
+// WithWatchDepth limits depth of subdirectories that App#WatchConfigDir watches.
+// With depth 0, only files directly in config directory are watched. Without the option, depth is unlimited.
+func WithWatchDepth(depth int) Option {
+	return func(a *App) {
+		a.opts.watchDepth = depth
+		a.opts.watchDepthLimited = true
+	}
+}
+
+// WithCacheAccessTracking makes App#ReadCacheFile and App#WriteCacheFile record last access time of cache files
+// to CacheAccessIndex file, so that App#PruneCache removes least recently used files correctly even if
+// atime is not updated. Only accesses through these methods are tracked.
+//
+// Each access appends a short line to the index, that costs one extra open and write.
+// The index is compacted by App#PruneCache.
+func WithCacheAccessTracking() Option {
+	return func(a *App) {
+		a.opts.cacheAccessTracking = true
+	}
+}
+
+// WithPrependExtraDirs makes extra directories take precedence over all other directories in search path.
+func WithPrependExtraDirs() Option {
+	return func(a *App) {
+		a.opts.prependExtraDirs = true
+	}
+}
+
+// WithNameTransform derives directory name of app from App.Name by given function, for example strings.ToLower
+// or prefixing with dot. The result is returned by App#CanonicalName and used as the directory segment in all trees.
+//
+// The result is validated instead of App.Name, and must be plain directory name that does not contain path separator.
+func WithNameTransform(f func(string) string) Option {
+	return func(a *App) {
+		a.opts.nameTransform = f
+	}
+}
+
+func (a *App) addExtraDirs(env string, dirs []string) {
+	m := make(map[string][]string, len(a.opt().extraDirs)+1)
+	for k, v := range a.opt().extraDirs {
+		m[k] = v
+	}
+	m[env] = append(append([]string(nil), m[env]...), dirs...)
+	a.opts.extraDirs = m
+}
@@ -0,0 +1,294 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAppWithFallbacks(t *testing.T) {
+	app := NewApp("test",
+		WithConfigFallback("etc/config"),
+		WithDataFallback(".mydata"),
+		WithCacheFallback("var/cache"))
+	os.Setenv("HOME", "h")
+	os.Setenv("XDG_CONFIG_HOME", "")
+	os.Setenv("XDG_DATA_HOME", "")
+	os.Setenv("XDG_CACHE_HOME", "")
+
+	table := []struct {
+		f        func() (string, error)
+		expected string
+	}{
+		{app.ConfigDir, path("h", "etc", "config", "test")},
+		{app.DataDir, path("h", ".mydata", "test")},
+		{app.CacheDir, path("h", "var", "cache", "test")},
+	}
+	for _, tbl := range table {
+		dir, err := tbl.f()
+		if err != nil {
+			t.Error(err)
+		}
+		if dir != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, dir)
+		}
+	}
+}
+
+func TestAppWithFallbacksPrefersEnv(t *testing.T) {
+	app := NewApp("test", WithConfigFallback("etc/config"))
+	os.Setenv("HOME", "h")
+	os.Setenv("XDG_CONFIG_HOME", "x")
+
+	dir, err := app.ConfigDir()
+	if err != nil {
+		t.Error(err)
+	}
+	if expected := path("x", "test"); dir != expected {
+		t.Errorf("expected %s, but got %s", expected, dir)
+	}
+}
+
+func TestAppWithPathListSeparator(t *testing.T) {
+	table := []struct {
+		sep      byte
+		dirs     string
+		expected []string
+	}{
+		{':', "a:b", []string{"a", "b"}},
+		{':', `C:\a;C:\b`, []string{"C", `\a;C`, `\b`}},
+		{';', `C:\a;C:\b`, []string{`C:\a`, `C:\b`}},
+		{';', "a:b", []string{"a:b"}},
+	}
+
+	for _, tbl := range table {
+		app := NewApp("test", WithPathListSeparator(tbl.sep))
+		os.Setenv("XDG_CONFIG_DIRS", tbl.dirs)
+		dirs := app.dirsForSearch("first", "XDG_CONFIG_DIRS")
+
+		expected := []string{"first"}
+		for _, d := range tbl.expected {
+			expected = append(expected, path(d, "test"))
+		}
+		if !reflect.DeepEqual(dirs, expected) {
+			t.Errorf("expected %v, but got %v", expected, dirs)
+		}
+	}
+}
+
+func TestAppWithEnviron(t *testing.T) {
+	os.Setenv("XDG_CONFIG_HOME", "x")
+	os.Setenv("HOME", "y")
+	os.Setenv("XDG_RUNTIME_DIR", "z")
+	app := NewApp("test", WithEnviron([]string{
+		"XDG_CONFIG_HOME=a",
+		"HOME=b",
+		"MALFORMED",
+		"=C:=C:\\",
+		"XDG_CONFIG_HOME=c",
+		"XDG_CONFIG_DIRS=d=e",
+	}))
+
+	table := []struct {
+		f        func() (string, error)
+		expected string
+	}{
+		{app.ConfigDir, path("c", "test")},
+		{app.DataDir, path("b", ".local", "share", "test")},
+		{app.CacheDir, path("b", ".cache", "test")},
+		{app.StateDir, path("b", ".local", "state", "test")},
+	}
+	for _, tbl := range table {
+		dir, err := tbl.f()
+		if err != nil {
+			t.Error(err)
+		}
+		if dir != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, dir)
+		}
+	}
+
+	if dir := app.RuntimeDir(); dir == path("z", "test") {
+		t.Errorf("XDG_RUNTIME_DIR of process should not be used, but got %s", dir)
+	}
+	dirs := app.dirsForSearch("first", "XDG_CONFIG_DIRS")
+	if expected := path("d=e", "test"); dirs[1] != expected {
+		t.Errorf("expected %s, but got %s", expected, dirs[1])
+	}
+}
+
+func TestAppWithEnvPrefix(t *testing.T) {
+	app := NewApp("test", WithEnvPrefix("MYAPP"))
+	os.Setenv("XDG_CONFIG_HOME", "x")
+	os.Setenv("XDG_DATA_HOME", "x")
+	os.Setenv("XDG_CACHE_HOME", "x")
+	os.Setenv("XDG_STATE_HOME", "x")
+	os.Setenv("XDG_RUNTIME_DIR", "x")
+	os.Setenv("MYAPP_CONFIG_HOME", "a")
+	os.Setenv("MYAPP_DATA_HOME", "b")
+	os.Setenv("MYAPP_CACHE_HOME", "")
+	os.Setenv("MYAPP_STATE_HOME", "d")
+	os.Setenv("MYAPP_RUNTIME_DIR", "e")
+	defer func() {
+		for _, k := range []string{"CONFIG_HOME", "DATA_HOME", "CACHE_HOME", "STATE_HOME", "RUNTIME_DIR"} {
+			os.Unsetenv("MYAPP_" + k)
+		}
+	}()
+
+	table := []struct {
+		f        func() (string, error)
+		expected string
+	}{
+		{app.ConfigDir, "a"},
+		{app.DataDir, "b"},
+		{app.CacheDir, path("x", "test")},
+		{app.StateDir, "d"},
+		{func() (string, error) { return app.RuntimeDir(), nil }, "e"},
+	}
+	for _, tbl := range table {
+		dir, err := tbl.f()
+		if err != nil {
+			t.Error(err)
+		}
+		if dir != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, dir)
+		}
+	}
+
+	if f, _ := app.ConfigFile("config.json"); f != path("a", "config.json") {
+		t.Errorf("expected %s, but got %s", path("a", "config.json"), f)
+	}
+	if dir, _ := NewApp("test").ConfigDir(); dir != path("x", "test") {
+		t.Errorf("prefixed envvar should be ignored without option, but got %s", dir)
+	}
+}
+
+func TestAppWithWorkingDir(t *testing.T) {
+	wd := path("/work")
+	env := []string{"XDG_CONFIG_HOME=conf", "XDG_CONFIG_DIRS=" + join("sys", path("/etc", "xdg")), "HOME=home", "XDG_RUNTIME_DIR=run"}
+
+	table := []struct {
+		name     string
+		opts     []Option
+		f        func(App) (string, error)
+		expected string
+	}{
+		{"config", nil, App.ConfigDir, path("conf", "test")},
+		{"config", []Option{WithWorkingDir(wd)}, App.ConfigDir, path(wd, "conf", "test")},
+		{"data", []Option{WithWorkingDir(wd)}, App.DataDir, path(wd, "home", ".local", "share", "test")},
+		{"runtime", []Option{WithWorkingDir(wd)}, func(a App) (string, error) { return a.RuntimeDir(), nil }, path(wd, "run", "test")},
+		{"prefixed", []Option{WithWorkingDir(wd), WithEnvPrefix("MYAPP"), WithEnviron(append(env, "MYAPP_CACHE_HOME=cache"))}, App.CacheDir, path(wd, "cache")},
+	}
+	for _, tbl := range table {
+		app := NewApp("test", append([]Option{WithEnviron(env)}, tbl.opts...)...)
+		dir, err := tbl.f(app)
+		if err != nil {
+			t.Error(err)
+		}
+		if dir != tbl.expected {
+			t.Errorf("%s: expected %s, but got %s", tbl.name, tbl.expected, dir)
+		}
+	}
+
+	app := NewApp("test", WithEnviron(env), WithWorkingDir(wd))
+	expected := []string{path(wd, "conf", "test"), path(wd, "sys", "test"), path("/etc", "xdg", "test")}
+	if dirs := app.ConfigDirs(); !reflect.DeepEqual(dirs, expected) {
+		t.Errorf("expected %v, but got %v", expected, dirs)
+	}
+}
+
+func TestAppWithNameTransform(t *testing.T) {
+	env := []string{"XDG_CONFIG_HOME=conf", "XDG_CONFIG_DIRS=sys", "XDG_RUNTIME_DIR=run"}
+	dot := func(s string) string { return "." + s }
+
+	table := []struct {
+		name      string
+		f         func(string) string
+		canonical string
+		config    string
+	}{
+		{"MyApp", nil, "MyApp", path("conf", "MyApp")},
+		{"MyApp", strings.ToLower, "myapp", path("conf", "myapp")},
+		{"MyApp", dot, ".MyApp", path("conf", ".MyApp")},
+		{"a/b", strings.ToLower, "a/b", ""},
+		{"MyApp", func(string) string { return ".." }, "..", ""},
+		{"MyApp", func(string) string { return "" }, "", ""},
+	}
+	for _, tbl := range table {
+		var opts []Option
+		if tbl.f != nil {
+			opts = append(opts, WithNameTransform(tbl.f))
+		}
+		app := NewApp(tbl.name, append(opts, WithEnviron(env))...)
+		if name := app.CanonicalName(); name != tbl.canonical {
+			t.Errorf("expected %s, but got %s", tbl.canonical, name)
+		}
+		dir, err := app.ConfigDir()
+		if tbl.config == "" {
+			if !errors.Is(err, ErrInvalidName) {
+				t.Errorf("%q should be invalid, but got %v", tbl.canonical, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+		}
+		if dir != tbl.config {
+			t.Errorf("expected %s, but got %s", tbl.config, dir)
+		}
+		expected := []string{tbl.config, path("sys", tbl.canonical)}
+		if dirs := app.ConfigDirs(); !reflect.DeepEqual(dirs, expected) {
+			t.Errorf("expected %v, but got %v", expected, dirs)
+		}
+		if dir, expected := app.RuntimeDir(), path("run", tbl.canonical); dir != expected {
+			t.Errorf("expected %s, but got %s", expected, dir)
+		}
+	}
+
+	app := NewApp("MyApp", WithNameTransform(strings.ToLower))
+	app.Name = "Other"
+	if name := app.CanonicalName(); name != "other" {
+		t.Errorf("expected other, but got %s", name)
+	}
+}
+
+func TestAppWithSyntheticUser(t *testing.T) {
+	home := t.TempDir()
+	app := NewApp("test", WithSyntheticUser(home, map[string]string{"XDG_CACHE_HOME": path(home, "tmp")}))
+
+	table := []struct {
+		f        func() (string, error)
+		expected string
+	}{
+		{app.ConfigDir, path(home, ".config", "test")},
+		{app.DataDir, path(home, ".local", "share", "test")},
+		{app.CacheDir, path(home, "tmp", "test")},
+	}
+	for _, tbl := range table {
+		dir, err := tbl.f()
+		if err != nil {
+			t.Error(err)
+		}
+		if dir != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, dir)
+		}
+	}
+	dir, err := NewApp("test", WithSyntheticUser(home, nil), WithGOOS("plan9")).StateDir()
+	if err != nil {
+		t.Error(err)
+	}
+	if expected := path(home, ".local", "state", "test"); dir != expected {
+		t.Errorf("expected %s, but got %s", expected, dir)
+	}
+
+	for _, opt := range []Option{
+		WithSyntheticUser("home", nil),
+		WithSyntheticUser(home, map[string]string{"HOME": path(home, "other")}),
+	} {
+		if _, err := NewApp("test", opt).ConfigDir(); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("expected ErrInvalidName, but got %v", err)
+		}
+	}
+}
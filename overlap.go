@@ -0,0 +1,115 @@
+package xdgdir
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// Overlaps reports whether any of config, data and cache directories of a overlaps with any of those of b,
+// that is, they are same directory or one contains the other.
+//
+// This detects that two differently named apps share files, for example because of name collision
+// or envvars that are specific to apps. Directories are compared after cleaning and resolving symlinks.
+// Symlinks are resolved through FileSystem of each app, up to the deepest directory that exists,
+// so directories that are not created yet under symlinked base directory are detected too.
+// For app that is created with WithNoFilesystemAccess option, directories are only cleaned and not resolved.
+func Overlaps(a, b App) (bool, error) {
+	da, err := resolvedDirs(a)
+	if err != nil {
+		return false, err
+	}
+	db, err := resolvedDirs(b)
+	if err != nil {
+		return false, err
+	}
+	for _, x := range da {
+		for _, y := range db {
+			if within(x, y) || within(y, x) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func resolvedDirs(a App) ([]string, error) {
+	var dirs []string
+	for _, f := range []func() (string, error){a.ConfigDir, a.DataDir, a.CacheDir} {
+		dir, err := f()
+		if err != nil {
+			return nil, err
+		}
+		if a.osAccess() != nil {
+			dirs = append(dirs, filepath.Clean(dir))
+			continue
+		}
+		dir, err = filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		dir, err = resolveSymlinks(a.fs(), dir)
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs, nil
+}
+
+// resolveSymlinks resolves symlinks in absolute path p through fsys, like filepath.EvalSymlinks.
+// Unlike filepath.EvalSymlinks, it does not fail for path that does not exist:
+// components from the first one that does not exist are joined to the resolved ancestor as they are.
+func resolveSymlinks(fsys FileSystem, p string) (string, error) {
+	sep := string(filepath.Separator)
+	vol := filepath.VolumeName(p)
+	resolved := vol + sep
+	parts := strings.Split(strings.TrimPrefix(p[len(vol):], sep), sep)
+	for links := 0; len(parts) > 0; {
+		part := parts[0]
+		parts = parts[1:]
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			resolved = filepath.Dir(resolved)
+			continue
+		}
+		next := filepath.Join(resolved, part)
+		fi, err := lstat(fsys, next)
+		if errors.Is(err, fs.ErrNotExist) {
+			return filepath.Join(append([]string{next}, parts...)...), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if fi.Mode()&fs.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+		if links++; links > 255 {
+			return "", fmt.Errorf("too many levels of symlinks in %s", p)
+		}
+		target, err := readlink(fsys, next)
+		if err != nil {
+			return "", err
+		}
+		if filepath.IsAbs(target) {
+			vol := filepath.VolumeName(target)
+			resolved = vol + sep
+			target = strings.TrimPrefix(target[len(vol):], sep)
+		}
+		parts = append(strings.Split(target, sep), parts...)
+	}
+	return resolved, nil
+}
+
+// within reports whether cleaned path p is dir or under dir.
+func within(p, dir string) bool {
+	if p == dir {
+		return true
+	}
+	return strings.HasPrefix(p, strings.TrimSuffix(dir, string(filepath.Separator))+string(filepath.Separator))
+}
@@ -0,0 +1,67 @@
+package xdgdir
+
+import (
+	"os"
+	"testing"
+)
+
+func TestOverlaps(t *testing.T) {
+	home := t.TempDir()
+	if err := os.MkdirAll(path(home, "real", "shared"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(path(home, "real"), path(home, "link")); err != nil {
+		t.Fatal(err)
+	}
+	base := []string{"XDG_CONFIG_HOME=" + path(home, "config"), "XDG_DATA_HOME=" + path(home, "data"), "XDG_CACHE_HOME=" + path(home, "cache")}
+	linked := []string{"XDG_CONFIG_HOME=" + path(home, "link"), "XDG_DATA_HOME=" + path(home, "data2"), "XDG_CACHE_HOME=" + path(home, "cache2")}
+
+	table := []struct {
+		a, b     App
+		expected bool
+	}{
+		{NewApp("one", WithEnviron(base)), NewApp("two", WithEnviron(base)), false},
+		{NewApp("one", WithEnviron(base)), NewApp("one", WithEnviron(base)), true},
+		{
+			NewApp("shared", WithEnviron(append(base, "XDG_CONFIG_HOME="+path(home, "real")))),
+			NewApp("shared", WithEnviron(linked)),
+			true,
+		},
+		{
+			NewApp("one", WithEnviron(base)),
+			NewApp("two", WithEnviron(append(base, "MYAPP_DATA_HOME="+path(home, "config"))), WithEnvPrefix("MYAPP")),
+			true,
+		},
+		// Directories that do not exist yet are resolved by their existing ancestor.
+		{
+			NewApp("new", WithEnviron(append(base, "XDG_CONFIG_HOME="+path(home, "real")))),
+			NewApp("new", WithEnviron(append(linked, "XDG_CONFIG_HOME="+path(home, "link", "sub", "..")))),
+			true,
+		},
+		// Without filesystem access, symlinks are not resolved.
+		{
+			NewApp("shared", WithEnviron(append(base, "XDG_CONFIG_HOME="+path(home, "real"))), WithNoFilesystemAccess()),
+			NewApp("shared", WithEnviron(linked), WithNoFilesystemAccess()),
+			false,
+		},
+		// memFS has no symlinks.
+		{
+			NewApp("shared", WithEnviron(append(base, "XDG_CONFIG_HOME="+path(home, "real"))), WithFileSystem(newMemFS())),
+			NewApp("shared", WithEnviron(linked), WithFileSystem(newMemFS())),
+			false,
+		},
+	}
+	for i, tbl := range table {
+		ok, err := Overlaps(tbl.a, tbl.b)
+		if err != nil {
+			t.Error(err)
+		}
+		if ok != tbl.expected {
+			t.Errorf("%d: expected %v, but got %v", i, tbl.expected, ok)
+		}
+	}
+
+	if _, err := Overlaps(NewApp(""), NewApp("two")); err == nil {
+		t.Error("should raise error for invalid app, but not raised")
+	}
+}
@@ -0,0 +1,53 @@
+package xdgdir
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+)
+
+// LoadPlugins calls parse for each file under subdir of app's data directories, such as plugin manifests.
+//
+// Data directories are walked in same order as FindDataFile. Files are identified by their path relative to subdir,
+// and a file in higher precedence directory shadows same file in lower ones, so parse is called once for each plugin.
+// Directories that do not have subdir are skipped, and symlinks are not followed.
+//
+// Errors that parse returns and errors of opening files are collected and returned together by errors.Join,
+// so that one broken plugin does not prevent others from loading.
+func (a App) LoadPlugins(subdir string, parse func(path string, r io.Reader) error) error {
+	if err := ValidateName(subdir); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	var errs []error
+	for layer, dir := range a.DataDirs() {
+		root := filepath.Join(dir, subdir)
+		err := a.walkFiles(root, layer, func(fp string, _ int, _ fs.DirEntry) error {
+			rel, err := filepath.Rel(root, fp)
+			if err != nil {
+				return err
+			}
+			if seen[rel] {
+				return nil
+			}
+			seen[rel] = true
+
+			f, err := a.fs().Open(fp)
+			if err != nil {
+				errs = append(errs, err)
+				return nil
+			}
+			defer f.Close()
+			if err := parse(fp, f); err != nil {
+				errs = append(errs, err)
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,56 @@
+package xdgdir
+
+import (
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestAppLoadPlugins(t *testing.T) {
+	user, system := t.TempDir(), t.TempDir()
+	os.Setenv("XDG_DATA_HOME", user)
+	os.Setenv("XDG_DATA_DIRS", system)
+	app := NewApp("test")
+
+	for fp, data := range map[string]string{
+		path(user, "test", "plugins", "a.json"):          "user a",
+		path(system, "test", "plugins", "a.json"):        "system a",
+		path(system, "test", "plugins", "b.json"):        "system b",
+		path(system, "test", "plugins", "sub", "c.json"): "broken",
+	} {
+		os.MkdirAll(path(fp, ".."), 0700)
+		if err := os.WriteFile(fp, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	broken := errors.New("broken")
+	loaded := map[string]string{}
+	err := app.LoadPlugins("plugins", func(fp string, r io.Reader) error {
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		if string(b) == "broken" {
+			return broken
+		}
+		loaded[fp] = string(b)
+		return nil
+	})
+	if !errors.Is(err, broken) {
+		t.Errorf("expected %v, but got %v", broken, err)
+	}
+	expected := map[string]string{
+		path(user, "test", "plugins", "a.json"):   "user a",
+		path(system, "test", "plugins", "b.json"): "system b",
+	}
+	if !reflect.DeepEqual(loaded, expected) {
+		t.Errorf("expected %v, but got %v", expected, loaded)
+	}
+
+	if err := app.LoadPlugins("none", func(string, io.Reader) error { return broken }); err != nil {
+		t.Errorf("missing subdir should be skipped, but got %v", err)
+	}
+}
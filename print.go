@@ -0,0 +1,90 @@
+package xdgdir
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+var kindNames = []struct {
+	kind   Kind
+	suffix string
+	env    string
+}{
+	{Config, "CONFIG_HOME", "XDG_CONFIG_HOME"},
+	{Data, "DATA_HOME", "XDG_DATA_HOME"},
+	{Cache, "CACHE_HOME", "XDG_CACHE_HOME"},
+	{State, "STATE_HOME", "XDG_STATE_HOME"},
+	{Runtime, "RUNTIME_DIR", "XDG_RUNTIME_DIR"},
+}
+
+// PrintPaths writes app's config, data, cache, state and runtime directories to w in aligned two columns,
+// like below. This is for CLI subcommand like "myapp paths".
+//
+//	config   /home/user/.config/myapp
+//	data     /home/user/.local/share/myapp
+//
+// If a directory cannot be resolved, the error is written in place of its path,
+// and the first such error is returned after all directories are written.
+func (a App) PrintPaths(w io.Writer) error {
+	return a.printPaths(w, false)
+}
+
+// PrintPathsVerbose is like PrintPaths, but also writes which envvar or rule each directory is resolved from.
+//
+//	config   /home/user/.config/myapp        (HOME)
+//	data     /home/user/data                 (XDG_DATA_HOME)
+func (a App) PrintPathsVerbose(w io.Writer) error {
+	return a.printPaths(w, true)
+}
+
+func (a App) printPaths(w io.Writer, verbose bool) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 3, ' ', 0)
+	var first error
+	for _, k := range kindNames {
+		dir, err := a.dir(k.kind)
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+			dir = fmt.Sprintf("<%v>", err)
+		}
+		line := k.kind.String() + "\t" + dir
+		if verbose && err == nil {
+			line += "\t(" + a.source(k.suffix, k.env) + ")"
+		}
+		if _, err := fmt.Fprintln(tw, line); err != nil {
+			return err
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	return first
+}
+
+// sourceTemp is source of runtime directory that falls back to temporary directory.
+const sourceTemp = "temp directory"
+
+// source returns name of envvar or rule that app's directory is resolved from.
+func (a App) source(suffix, env string) string {
+	if a.opt().layout != nil && a.opt().layout.dir(suffix) != "" {
+		return "layout"
+	}
+	if a.prefixedEnv(suffix) != "" {
+		return a.opt().envPrefix + "_" + suffix
+	}
+	if strings.TrimSpace(a.getenv(env)) != "" {
+		return env
+	}
+	if env == "XDG_RUNTIME_DIR" {
+		return sourceTemp
+	}
+	for _, env := range homeEnvs(a.goos()) {
+		if a.getenv(env) != "" {
+			return env
+		}
+	}
+	return ""
+}
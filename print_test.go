@@ -0,0 +1,48 @@
+package xdgdir
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestAppPrintPaths(t *testing.T) {
+	env := []string{"HOME=/home/u", "XDG_DATA_HOME=/data", "XDG_RUNTIME_DIR=/run/u", "MYAPP_CACHE_HOME=/cache"}
+	app := NewApp("test", WithEnviron(env), WithEnvPrefix("MYAPP"))
+
+	var b bytes.Buffer
+	if err := app.PrintPaths(&b); err != nil {
+		t.Fatal(err)
+	}
+	expected := "config    " + path("/home", "u", ".config", "test") + "\n" +
+		"data      " + path("/data", "test") + "\n" +
+		"cache     " + path("/cache") + "\n" +
+		"state     " + path("/home", "u", ".local", "state", "test") + "\n" +
+		"runtime   " + path("/run", "u", "test") + "\n"
+	if b.String() != expected {
+		t.Errorf("expected %q, but got %q", expected, b.String())
+	}
+
+	b.Reset()
+	if err := app.PrintPathsVerbose(&b); err != nil {
+		t.Fatal(err)
+	}
+	expected = fmt.Sprintf("config    %-28s(HOME)\n", path("/home", "u", ".config", "test")) +
+		fmt.Sprintf("data      %-28s(XDG_DATA_HOME)\n", path("/data", "test")) +
+		fmt.Sprintf("cache     %-28s(MYAPP_CACHE_HOME)\n", path("/cache")) +
+		fmt.Sprintf("state     %-28s(HOME)\n", path("/home", "u", ".local", "state", "test")) +
+		fmt.Sprintf("runtime   %-28s(XDG_RUNTIME_DIR)\n", path("/run", "u", "test"))
+	if b.String() != expected {
+		t.Errorf("expected %q, but got %q", expected, b.String())
+	}
+}
+
+func TestAppPrintPathsError(t *testing.T) {
+	var b bytes.Buffer
+	if err := NewApp("test", WithEnviron(nil)).PrintPaths(&b); err == nil {
+		t.Error("should raise error, but not raised")
+	}
+	if b.Len() == 0 {
+		t.Error("paths should be written even if error is raised")
+	}
+}
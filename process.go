@@ -0,0 +1,15 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package xdgdir
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processAlive reports whether process of pid exists. Process of another user also exists, though it cannot be signaled.
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,9 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!windows
+
+package xdgdir
+
+// processAlive always reports that process is alive, because this platform cannot check it.
+func processAlive(pid int) bool {
+	return true
+}
@@ -0,0 +1,60 @@
+package xdgdir
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// ProfileConfigDir returns config directory of app's profile that is set by WithProfile option.
+//
+// Profile directory is {{ConfigDir}}/{{Profile}}. If no profile is set, returns App#ConfigDir.
+func (a App) ProfileConfigDir() (string, error) {
+	if a.opt().profile == "" {
+		return a.ConfigDir()
+	}
+	if err := validateProfile(a.opt().profile); err != nil {
+		return "", err
+	}
+	return a.ConfigFile(a.opt().profile)
+}
+
+// Profiles returns names of app's profiles in lexical order.
+//
+// Every immediate subdirectory of App#ConfigDir is a profile,
+// except hidden directories whose names start with ".", which are reserved for the app's own use.
+// If App#ConfigDir does not exist, returns empty.
+func (a App) Profiles() ([]string, error) {
+	dir, err := a.ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := a.fs().ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles []string
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			profiles = append(profiles, e.Name())
+		}
+	}
+	sort.Strings(profiles)
+	return profiles, nil
+}
+
+func validateProfile(profile string) error {
+	if err := ValidateName(profile); err != nil {
+		return err
+	}
+	if strings.HasPrefix(profile, ".") || strings.ContainsAny(profile, `/\`) {
+		return fmt.Errorf("%w: profile %q must be plain directory name", ErrInvalidName, profile)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestAppProfileConfigDir(t *testing.T) {
+	os.Setenv("XDG_CONFIG_HOME", "a")
+	table := []struct {
+		profile  string
+		expected string
+		err      bool
+	}{
+		{"", path("a", "test"), false},
+		{"work", path("a", "test", "work"), false},
+		{".hidden", "", true},
+		{"a/b", "", true},
+		{"..", "", true},
+	}
+	for _, tbl := range table {
+		dir, err := NewApp("test", WithProfile(tbl.profile)).ProfileConfigDir()
+		if tbl.err {
+			if !errors.Is(err, ErrInvalidName) {
+				t.Errorf("expected ErrInvalidName for %q, but got %v", tbl.profile, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+		}
+		if dir != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, dir)
+		}
+	}
+}
+
+func TestAppProfiles(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if profiles, err := app.Profiles(); err != nil || len(profiles) != 0 {
+		t.Errorf("expected no profiles, but got %v (%v)", profiles, err)
+	}
+
+	dir, _ := app.ConfigDir()
+	for _, d := range []string{"work", "home", ".cache"} {
+		if err := os.MkdirAll(path(dir, d), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(path(dir, "config.txt"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	profiles, err := app.Profiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"home", "work"}; !reflect.DeepEqual(profiles, expected) {
+		t.Errorf("expected %v, but got %v", expected, profiles)
+	}
+}
@@ -0,0 +1,46 @@
+package xdgdir
+
+import "path/filepath"
+
+// FindProjectConfig finds project local config file among candidate names, like ".myapp.toml",
+// by walking up from startDir to its parents, and falls back to App#FindFirstConfigFile if none is found.
+// This is "local overrides global" discovery of tools like linters and formatters.
+//
+// In each directory, candidates are tried in given order. Walking stops after directory that contains .git,
+// which is the root of repository, or before crossing filesystem boundary such as mount point.
+// Relative startDir is resolved against working directory of WithWorkingDir option or of the process.
+func (a App) FindProjectConfig(startDir string, names ...string) (string, error) {
+	for _, name := range names {
+		if err := ValidateName(name); err != nil {
+			return "", err
+		}
+	}
+	dir, err := filepath.Abs(a.anchor(startDir))
+	if err != nil {
+		return "", err
+	}
+
+	fsys := a.fs()
+	prev, err := fsys.Stat(dir)
+	for err == nil && prev.IsDir() {
+		for _, name := range names {
+			fp := filepath.Join(dir, name)
+			if fi, err := fsys.Stat(fp); err == nil && fi.Mode().IsRegular() {
+				return fp, nil
+			}
+		}
+		if _, err := fsys.Stat(filepath.Join(dir, ".git")); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		fi, statErr := fsys.Stat(parent)
+		if statErr != nil || !sameDevice(prev, fi) {
+			break
+		}
+		dir, prev = parent, fi
+	}
+	return a.FindFirstConfigFile(names...)
+}
@@ -0,0 +1,58 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestAppFindProjectConfig(t *testing.T) {
+	dir := t.TempDir()
+	app := NewApp("test", WithEnviron([]string{"XDG_CONFIG_HOME=" + path(dir, "config"), "XDG_CONFIG_DIRS=" + path(dir, "none")}))
+	for _, d := range []string{path("outside"), path("outside", "repo", ".git"), path("outside", "repo", "pkg", "sub"), path("config", "test")} {
+		if err := os.MkdirAll(path(dir, d), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	start := path(dir, "outside", "repo", "pkg", "sub")
+
+	if _, err := app.FindProjectConfig(start, ".test.toml", "test.toml"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, but got %v", err)
+	}
+
+	table := []struct {
+		file     string
+		expected string
+	}{
+		// Above repository root is not searched.
+		{path("outside", ".test.toml"), ""},
+		{path("config", "test", "test.toml"), path(dir, "config", "test", "test.toml")},
+		{path("outside", "repo", "test.toml"), path(dir, "outside", "repo", "test.toml")},
+		{path("outside", "repo", "pkg", "test.toml"), path(dir, "outside", "repo", "pkg", "test.toml")},
+		// Earlier candidate wins in same directory.
+		{path("outside", "repo", "pkg", ".test.toml"), path(dir, "outside", "repo", "pkg", ".test.toml")},
+	}
+	for _, tbl := range table {
+		if err := os.WriteFile(path(dir, tbl.file), nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if tbl.expected == "" {
+			continue
+		}
+		fp, err := app.FindProjectConfig(start, ".test.toml", "test.toml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fp != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, fp)
+		}
+	}
+
+	wd := NewApp("test", WithWorkingDir(path(dir, "outside", "repo")))
+	if fp, _ := wd.FindProjectConfig("pkg", "test.toml"); fp != path(dir, "outside", "repo", "pkg", "test.toml") {
+		t.Errorf("relative start dir should be resolved against working dir, but got %s", fp)
+	}
+	if _, err := app.FindProjectConfig(start, "a\x00"); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName, but got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+)
+
+// PruneEmptyDirs removes directories that contain no files from app's config, data and cache directories,
+// and returns removed directories.
+//
+// Directories are walked bottom-up, so directories that contain only empty directories are removed too.
+// App's base directories themselves are left, and symlinks are not followed.
+func (a App) PruneEmptyDirs() ([]string, error) {
+	var removed []string
+	for _, f := range []func() (string, error){a.ConfigDir, a.DataDir, a.CacheDir} {
+		dir, err := f()
+		if err != nil {
+			return removed, err
+		}
+		if _, err := a.pruneDir(dir, &removed); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return removed, err
+		}
+	}
+	return removed, nil
+}
+
+// pruneDir removes empty subdirectories of dir, and reports whether dir became empty.
+func (a App) pruneDir(dir string, removed *[]string) (bool, error) {
+	entries, err := a.fs().ReadDir(dir)
+	if err != nil {
+		return false, err
+	}
+
+	empty := true
+	for _, e := range entries {
+		if !e.IsDir() {
+			empty = false
+			continue
+		}
+		sub := filepath.Join(dir, e.Name())
+		subEmpty, err := a.pruneDir(sub, removed)
+		if err != nil {
+			return false, err
+		}
+		if !subEmpty {
+			empty = false
+			continue
+		}
+		if err := a.fs().Remove(sub); err != nil {
+			return false, err
+		}
+		*removed = append(*removed, sub)
+	}
+	return empty, nil
+}
@@ -0,0 +1,54 @@
+package xdgdir
+
+import (
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestAppPruneEmptyDirs(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	os.Setenv("XDG_DATA_HOME", t.TempDir())
+	os.Setenv("XDG_CACHE_HOME", t.TempDir())
+	outside := t.TempDir()
+
+	conf, _ := app.EnsureConfigDir()
+	data, _ := app.EnsureDataDir()
+	for _, d := range []string{
+		path(conf, "a", "b", "c"),
+		path(conf, "keep"),
+		path(data, "x"),
+	} {
+		if err := os.MkdirAll(d, 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(path(conf, "keep", "file.txt"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, path(data, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := app.PruneEmptyDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		path(conf, "a"),
+		path(conf, "a", "b"),
+		path(conf, "a", "b", "c"),
+		path(data, "x"),
+	}
+	sort.Strings(removed)
+	if !reflect.DeepEqual(removed, expected) {
+		t.Errorf("expected %v, but got %v", expected, removed)
+	}
+	for _, d := range []string{conf, data, path(conf, "keep"), path(data, "link"), outside} {
+		if _, err := os.Lstat(d); err != nil {
+			t.Errorf("%s should not be removed", d)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package xdgdir
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+)
+
+// ReplaceConfigTree replaces app's config directory with contents of src as a whole, for importing settings bundle.
+//
+// src is first staged into temporary sibling directory of the config directory, and then swapped in by renames:
+// the old directory is renamed aside, the staged one is renamed in, and the old one is removed.
+// So readers never see half-applied import, though the directory is briefly missing between the renames.
+// Because staging is in the parent of the config directory, renames never cross filesystems.
+// If staging or swapping fails, the original directory is restored and the staged one is removed.
+//
+// If the directory is swapped but syncing its parent fails, the old directory is still removed,
+// and the returned error reports that the new contents may not be durable yet.
+//
+// Only directories and regular files of src are copied.
+func (a App) ReplaceConfigTree(src fs.FS) error {
+	dir, err := a.ConfigDir()
+	if err != nil {
+		return err
+	}
+	mu := lockPath(dir)
+	mu.Lock()
+	defer mu.Unlock()
+
+	fsys := a.fs()
+	if err := fsys.MkdirAll(filepath.Dir(dir), 0700); err != nil {
+		return err
+	}
+	staging := tempName(dir)
+	if err := stageTree(src, fsys, staging); err != nil {
+		removeAll(fsys, staging)
+		return err
+	}
+
+	old := tempName(dir)
+	hadOld := true
+	if err := fsys.Rename(dir, old); errors.Is(err, fs.ErrNotExist) {
+		hadOld = false
+	} else if err != nil {
+		removeAll(fsys, staging)
+		return err
+	}
+	if err := fsys.Rename(staging, dir); err != nil {
+		if hadOld {
+			if rerr := fsys.Rename(old, dir); rerr != nil {
+				err = fmt.Errorf("%w, and failed to restore %s from %s: %v", err, dir, old, rerr)
+			}
+		}
+		removeAll(fsys, staging)
+		return err
+	}
+
+	// The swap is done and cannot be rolled back, so the old directory is removed even if sync fails.
+	syncErr := a.syncDir(filepath.Dir(dir))
+	var removeErr error
+	if hadOld {
+		removeErr = removeAll(fsys, old)
+	}
+	if syncErr != nil {
+		syncErr = fmt.Errorf("%s is replaced, but failed to sync its parent: %w", dir, syncErr)
+	}
+	return errors.Join(syncErr, removeErr)
+}
+
+// stageTree copies directories and regular files of src into new directory dir of fsys, and fsyncs files.
+func stageTree(src fs.FS, fsys FileSystem, dir string) error {
+	return fs.WalkDir(src, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(dir, filepath.FromSlash(p))
+		switch {
+		case d.IsDir():
+			return fsys.MkdirAll(dst, 0700)
+		case d.Type().IsRegular():
+			r, err := src.Open(p)
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+			f, err := fsys.Create(dst)
+			if err != nil {
+				return err
+			}
+			return writeAndSync(f, r)
+		}
+		return nil
+	})
+}
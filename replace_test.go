@@ -0,0 +1,130 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+// brokenFS is fs.FS that fails to open file named "broken".
+type brokenFS struct {
+	fstest.MapFS
+}
+
+func (b brokenFS) Open(name string) (fs.File, error) {
+	if name == "broken" {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+	}
+	return b.MapFS.Open(name)
+}
+
+func TestAppReplaceConfigTree(t *testing.T) {
+	home := t.TempDir()
+	app := NewApp("test", WithEnviron([]string{"XDG_CONFIG_HOME=" + path(home, "config")}))
+	files := func() map[string]string {
+		m := map[string]string{}
+		dir, _ := app.ConfigDir()
+		app.walkFiles(dir, 0, func(fp string, _ int, _ fs.DirEntry) error {
+			b, _ := os.ReadFile(fp)
+			m[fp[len(dir)+1:]] = string(b)
+			return nil
+		})
+		return m
+	}
+
+	table := []fstest.MapFS{
+		{"config.toml": {Data: []byte("v1")}, "old.toml": {Data: []byte("old")}},
+		{"config.toml": {Data: []byte("v2")}, "themes/dark.css": {Data: []byte("dark")}},
+	}
+	for _, src := range table {
+		if err := app.ReplaceConfigTree(src); err != nil {
+			t.Fatal(err)
+		}
+		expected := map[string]string{}
+		for name, f := range src {
+			expected[path(name)] = string(f.Data)
+		}
+		if actual := files(); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("expected %v, but got %v", expected, actual)
+		}
+	}
+
+	before := files()
+	err := app.ReplaceConfigTree(brokenFS{fstest.MapFS{"a.toml": {}, "broken": {}}})
+	if !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("expected permission error, but got %v", err)
+	}
+	if actual := files(); !reflect.DeepEqual(actual, before) {
+		t.Errorf("original tree should be kept, expected %v, but got %v", before, actual)
+	}
+	entries, err := os.ReadDir(path(home, "config"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "test" {
+		t.Errorf("temporary directories should be removed, but got %v", entries)
+	}
+}
+
+// syncFailFS is FileSystem that fails to open directory dir, so that syncing it fails.
+type syncFailFS struct {
+	osFileSystem
+	dir string
+}
+
+func (s syncFailFS) Open(name string) (fs.File, error) {
+	if name == s.dir {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+	}
+	return s.osFileSystem.Open(name)
+}
+
+func TestAppReplaceConfigTreeSyncError(t *testing.T) {
+	home := t.TempDir()
+	env := []string{"XDG_CONFIG_HOME=" + home}
+	app := NewApp("test", WithEnviron(env), WithGOOS("linux"), WithFileSystem(syncFailFS{dir: home}))
+	if err := NewApp("test", WithEnviron(env)).WriteConfigFile("old.toml", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	err := app.ReplaceConfigTree(fstest.MapFS{"config.toml": {Data: []byte("new")}})
+	if !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("expected permission error, but got %v", err)
+	}
+	if s, _ := openFile(path(home, "test", "config.toml")); s != "new" {
+		t.Errorf("new tree should be kept, but got %q", s)
+	}
+	entries, err := os.ReadDir(home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "test" {
+		t.Errorf("old directory should be removed, but got %v", entries)
+	}
+}
+
+func TestAppReplaceConfigTreeFileSystem(t *testing.T) {
+	fsys := newMemFS()
+	home := t.TempDir()
+	app := NewApp("test", WithEnviron([]string{"XDG_CONFIG_HOME=" + home}), WithFileSystem(fsys))
+	if err := app.WriteConfigFile("old.toml", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.ReplaceConfigTree(fstest.MapFS{"config.toml": {Data: []byte("new")}}); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := fsys.ReadDir(path(home, "test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "config.toml" {
+		t.Errorf("expected only config.toml, but got %v", entries)
+	}
+	if _, err := os.Stat(path(home, "test")); !os.IsNotExist(err) {
+		t.Error("OS filesystem should not be touched")
+	}
+}
@@ -0,0 +1,66 @@
+package xdgdir
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+)
+
+// Located is file that is found by FindResource.
+type Located struct {
+	// Path of found file
+	Path string
+	// Tree where the file is found, Config or Data
+	Tree Kind
+	// Layer is precedence of directory in the tree: 0 is app's user directory, and higher layers are system directories.
+	Layer int
+	// Info is file info that is obtained while searching.
+	Info fs.FileInfo
+}
+
+// FindResource finds app's resource that has given name in config files, and then in data files.
+//
+// 1. Search in config directories in same order as FindConfigFile.
+// 2. Search in data directories in same order as FindDataFile.
+//
+// Returned Located tells which tree and layer the file is found in, which helps to debug why the file is picked.
+func (a App) FindResource(names ...string) (Located, error) {
+	if err := a.validateName(); err != nil {
+		return Located{}, err
+	}
+	np := filepath.Join(names...)
+	if err := ValidateName(np); err != nil {
+		return Located{}, err
+	}
+
+	trees := []struct {
+		kind Kind
+		dir  func() (string, error)
+		env  string
+	}{
+		{Config, a.ConfigDir, "XDG_CONFIG_DIRS"},
+		{Data, a.DataDir, "XDG_DATA_DIRS"},
+	}
+	for _, tree := range trees {
+		d, _ := tree.dir()
+		for layer, dir := range a.dirsForSearch(d, tree.env) {
+			if dir == "" {
+				continue
+			}
+			fp := filepath.Join(dir, np)
+			if fi, err := a.fs().Stat(fp); err == nil {
+				return Located{Path: fp, Tree: tree.kind, Layer: layer, Info: fi}, nil
+			}
+		}
+	}
+	return Located{}, fmt.Errorf("resource %s is %w", np, ErrNotFound)
+}
+
+// FindResourcePath is like FindResource, but returns only path of found file.
+func (a App) FindResourcePath(names ...string) (string, error) {
+	l, err := a.FindResource(names...)
+	if err != nil {
+		return "", err
+	}
+	return l.Path, nil
+}
@@ -0,0 +1,46 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestAppFindResource(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "z"))
+	os.Setenv("XDG_CONFIG_DIRS", path("testdata", "a"))
+	os.Setenv("XDG_DATA_HOME", path("testdata", "b"))
+	os.Setenv("XDG_DATA_DIRS", join(path("testdata", "a"), path("testdata", "c")))
+
+	table := []struct {
+		name  string
+		path  string
+		tree  Kind
+		layer int
+	}{
+		{"aaa.txt", path("testdata", "a", "test", "aaa.txt"), Config, 1},
+		{"bbb.txt", path("testdata", "b", "test", "bbb.txt"), Data, 0},
+		{"ccc.txt", path("testdata", "c", "test", "ccc.txt"), Data, 2},
+	}
+	for _, tbl := range table {
+		l, err := app.FindResource(tbl.name)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if l.Path != tbl.path || l.Tree != tbl.tree || l.Layer != tbl.layer {
+			t.Errorf("expected %s in %d layer %d, but got %s in %d layer %d", tbl.path, tbl.tree, tbl.layer, l.Path, l.Tree, l.Layer)
+		}
+		if l.Info == nil || l.Info.Name() != tbl.name {
+			t.Errorf("expected info of %s, but got %v", tbl.name, l.Info)
+		}
+		if fp, _ := app.FindResourcePath(tbl.name); fp != tbl.path {
+			t.Errorf("expected %s, but got %s", tbl.path, fp)
+		}
+	}
+
+	if _, err := app.FindResource("none.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected %v, but got %v", ErrNotFound, err)
+	}
+}
@@ -0,0 +1,135 @@
+package xdgdir
+
+import (
+	"io/fs"
+	"time"
+)
+
+// WithRetry makes App retry filesystem operations that fail with transient errors,
+// such as interrupted system calls on network filesystems.
+//
+// Each operation is tried at most attempts times. Before each retry, App waits backoff,
+// and the wait is doubled after each retry. If all attempts fail, the last error is returned.
+// Errors that are not transient, such as not found or permission denied, are returned immediately.
+//
+// Following errors are treated as transient:
+//
+// - On Unix: EINTR, EAGAIN, EBUSY and ETXTBSY.
+// - On js: EINTR, EAGAIN and EBUSY.
+// - On Windows: ERROR_SHARING_VIOLATION and ERROR_LOCK_VIOLATION.
+// - On Plan 9: none.
+func WithRetry(attempts int, backoff time.Duration) Option {
+	return func(a *App) {
+		a.opts.retryAttempts = attempts
+		a.opts.retryBackoff = backoff
+	}
+}
+
+// retryFS is FileSystem that retries operations of underlying FileSystem on transient errors.
+type retryFS struct {
+	fsys     FileSystem
+	attempts int
+	backoff  time.Duration
+}
+
+func (r retryFS) do(op func() error) error {
+	wait := r.backoff
+	var err error
+	for i := 0; i < r.attempts; i++ {
+		if i > 0 {
+			time.Sleep(wait)
+			wait *= 2
+		}
+		if err = op(); err == nil || !isTransient(err) {
+			return err
+		}
+	}
+	return err
+}
+
+func (r retryFS) Stat(name string) (fi fs.FileInfo, err error) {
+	err = r.do(func() error {
+		fi, err = r.fsys.Stat(name)
+		return err
+	})
+	return fi, err
+}
+
+func (r retryFS) Open(name string) (f fs.File, err error) {
+	err = r.do(func() error {
+		f, err = r.fsys.Open(name)
+		return err
+	})
+	return f, err
+}
+
+func (r retryFS) Create(name string) (f File, err error) {
+	err = r.do(func() error {
+		f, err = r.fsys.Create(name)
+		return err
+	})
+	return f, err
+}
+
+func (r retryFS) MkdirAll(path string, perm fs.FileMode) error {
+	return r.do(func() error { return r.fsys.MkdirAll(path, perm) })
+}
+
+func (r retryFS) ReadDir(name string) (entries []fs.DirEntry, err error) {
+	err = r.do(func() error {
+		entries, err = r.fsys.ReadDir(name)
+		return err
+	})
+	return entries, err
+}
+
+func (r retryFS) Rename(oldpath, newpath string) error {
+	return r.do(func() error { return r.fsys.Rename(oldpath, newpath) })
+}
+
+func (r retryFS) Remove(name string) error {
+	return r.do(func() error { return r.fsys.Remove(name) })
+}
+
+func (r retryFS) Chmod(name string, mode fs.FileMode) error {
+	return r.do(func() error { return chmod(r.fsys, name, mode) })
+}
+
+// OpenFile returns error wrapping errUnsupported if underlying FileSystem does not support OpenFile method.
+func (r retryFS) OpenFile(name string, flag int, perm fs.FileMode) (f File, err error) {
+	o, ok := r.fsys.(interface {
+		OpenFile(name string, flag int, perm fs.FileMode) (File, error)
+	})
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: errUnsupported}
+	}
+	err = r.do(func() error {
+		f, err = o.OpenFile(name, flag, perm)
+		return err
+	})
+	return f, err
+}
+
+func (r retryFS) Lstat(name string) (fi fs.FileInfo, err error) {
+	err = r.do(func() error {
+		fi, err = lstatNoFollow(r.fsys, name)
+		return err
+	})
+	return fi, err
+}
+
+func (r retryFS) Symlink(oldname, newname string) error {
+	return r.do(func() error { return symlink(r.fsys, oldname, newname) })
+}
+
+func (r retryFS) Readlink(name string) (dest string, err error) {
+	err = r.do(func() error {
+		dest, err = readlink(r.fsys, name)
+		return err
+	})
+	return dest, err
+}
+
+func (r retryFS) RemoveAll(path string) error {
+	return r.do(func() error { return removeAll(r.fsys, path) })
+}
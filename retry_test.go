@@ -0,0 +1,55 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package xdgdir
+
+import (
+	"io/fs"
+	"syscall"
+	"testing"
+)
+
+func TestAppWithRetry(t *testing.T) {
+	table := []struct {
+		attempts int
+		fails    int
+		err      error
+		calls    int
+		found    bool
+	}{
+		{3, 2, syscall.EAGAIN, 3, true},
+		{3, 5, syscall.EINTR, 3, false},
+		{3, 1, syscall.EACCES, 1, false},
+		{0, 1, syscall.EAGAIN, 1, false},
+	}
+	for _, tbl := range table {
+		fsys := &flakyFS{fails: tbl.fails, err: tbl.err}
+		app := NewApp("test", WithFileSystem(fsys), WithRetry(tbl.attempts, 0))
+		_, err := app.fs().Stat(path("testdata", "a", "test", "aaa.txt"))
+		if tbl.found && err != nil {
+			t.Error(err)
+		}
+		if !tbl.found && err == nil {
+			t.Errorf("should raise error for %v, but not raised", tbl.err)
+		}
+		if fsys.calls != tbl.calls {
+			t.Errorf("expected %d calls for %v, but got %d", tbl.calls, tbl.err, fsys.calls)
+		}
+	}
+}
+
+// flakyFS is FileSystem whose Stat fails with err for first fails calls.
+type flakyFS struct {
+	osFileSystem
+	fails int
+	err   error
+	calls int
+}
+
+func (f *flakyFS) Stat(name string) (fs.FileInfo, error) {
+	f.calls++
+	if f.calls <= f.fails {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: f.err}
+	}
+	return f.osFileSystem.Stat(name)
+}
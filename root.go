@@ -0,0 +1,38 @@
+//go:build go1.24
+// +build go1.24
+
+package xdgdir
+
+import "os"
+
+// ConfigRoot creates app's config directory if it does not exist, and returns os.Root that is rooted there.
+//
+// File operations through the root cannot escape the directory, neither by ".." nor by symlinks.
+// The root is on the OS filesystem, even if App is created with WithFileSystem option. Close it after use.
+func (a App) ConfigRoot() (*os.Root, error) {
+	return a.openRoot(a.ConfigDir)
+}
+
+// DataRoot is like ConfigRoot, but is rooted at app's data directory.
+func (a App) DataRoot() (*os.Root, error) {
+	return a.openRoot(a.DataDir)
+}
+
+// CacheRoot is like ConfigRoot, but is rooted at app's cache directory, that has namespace of WithCacheNamespace option.
+func (a App) CacheRoot() (*os.Root, error) {
+	return a.openRoot(a.cacheNamespaceDir)
+}
+
+func (a App) openRoot(f func() (string, error)) (*os.Root, error) {
+	if err := a.osAccess(); err != nil {
+		return nil, err
+	}
+	dir, err := f()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return os.OpenRoot(dir)
+}
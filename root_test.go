@@ -0,0 +1,65 @@
+//go:build go1.24
+// +build go1.24
+
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestAppRoots(t *testing.T) {
+	home := t.TempDir()
+	app := NewApp("test", WithEnviron([]string{"HOME=" + home}))
+	if err := os.WriteFile(path(home, "secret.txt"), []byte("secret"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(path(home, ".config", "test"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(path(home, "secret.txt"), path(home, ".config", "test", "link.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	table := []struct {
+		name string
+		f    func() (*os.Root, error)
+		dir  func() (string, error)
+	}{
+		{"config", app.ConfigRoot, app.ConfigDir},
+		{"data", app.DataRoot, app.DataDir},
+		{"cache", app.CacheRoot, app.CacheDir},
+	}
+	for _, tbl := range table {
+		root, err := tbl.f()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer root.Close()
+		if dir, _ := tbl.dir(); root.Name() != dir {
+			t.Errorf("%s: expected %s, but got %s", tbl.name, dir, root.Name())
+		}
+		f, err := root.Create("file.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+		if _, err := root.Open(path("..", "..", "secret.txt")); err == nil {
+			t.Errorf("%s: root should not escape by ..", tbl.name)
+		}
+	}
+
+	root, err := app.ConfigRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+	if _, err := root.Open("link.txt"); err == nil {
+		t.Error("root should not escape by symlink")
+	}
+
+	if _, err := NewApp("test", WithNoFilesystemAccess()).ConfigRoot(); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly, but got %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package xdgdir
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AppendStateFileRotating appends data to app's state file that has given name, such as history or debug log.
+//
+// If appending data would make the file larger than maxBytes, the file is rotated before appending:
+// {{name}} is renamed to {{name}}.1, {{name}}.1 to {{name}}.2 and so on, and files beyond {{name}}.{{keep}} are removed.
+// With keep 0, the file is just removed. Rotated files that are missing are skipped.
+// Since rotation is done by renames, concurrent readers never see truncated file.
+//
+// Appends and rotations of same file are serialized within a process.
+func (a App) AppendStateFileRotating(name string, data []byte, maxBytes int64, keep int) error {
+	fp, err := a.StateFile(name)
+	if err != nil {
+		return err
+	}
+	if err := a.osAccess(); err != nil {
+		return err
+	}
+
+	mu := lockPath(fp)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if fi, err := a.fs().Stat(fp); err == nil && fi.Size() > 0 && fi.Size()+int64(len(data)) > maxBytes {
+		if err := a.rotate(fp, keep); err != nil {
+			return err
+		}
+	}
+
+	if err := a.fs().MkdirAll(filepath.Dir(fp), 0700); err != nil {
+		return err
+	}
+	return appendFile(a.fs(), fp, data)
+}
+
+func (a App) rotate(fp string, keep int) error {
+	fsys := a.fs()
+	if keep <= 0 {
+		return ignoreNotExist(fsys.Remove(fp))
+	}
+	if err := ignoreNotExist(fsys.Remove(rotated(fp, keep))); err != nil {
+		return err
+	}
+	for i := keep - 1; i >= 1; i-- {
+		if err := ignoreNotExist(fsys.Rename(rotated(fp, i), rotated(fp, i+1))); err != nil {
+			return err
+		}
+	}
+	return fsys.Rename(fp, rotated(fp, 1))
+}
+
+func rotated(fp string, n int) string {
+	return fmt.Sprintf("%s.%d", fp, n)
+}
+
+func ignoreNotExist(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// OpenLatestStateFile opens the freshest available variant of app's state file that has given name,
+// which is rotated by AppendStateFileRotating.
+//
+// 1. If {{name}} exists, opens it.
+// 2. Otherwise, opens rotated file {{name}}.{{N}} that has the lowest N, which is the most recently rotated.
+// If several files have the same N, such as {{name}}.1 and {{name}}.01, the most recently modified one is opened.
+//
+// If no variant exists, returns error that wraps ErrNotFound, which also satisfies errors.Is(err, fs.ErrNotExist).
+// The file is opened on the OS filesystem, even if App is created with WithFileSystem option.
+func (a App) OpenLatestStateFile(name string) (*os.File, error) {
+	fp, err := a.StateFile(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.osAccess(); err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(fp)
+	if err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return f, err
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(fp))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	base := filepath.Base(fp) + "."
+	latest, latestN := "", -1
+	var latestMod time.Time
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), base) {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimPrefix(e.Name(), base))
+		if err != nil || n < 1 {
+			continue
+		}
+		fi, err := e.Info()
+		if err != nil {
+			continue
+		}
+		if latestN < 0 || n < latestN || (n == latestN && fi.ModTime().After(latestMod)) {
+			latest, latestN, latestMod = e.Name(), n, fi.ModTime()
+		}
+	}
+	if latest == "" {
+		return nil, fmt.Errorf("state file %s is %w", name, ErrNotFound)
+	}
+	return os.Open(filepath.Join(filepath.Dir(fp), latest))
+}
@@ -0,0 +1,126 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestAppAppendStateFileRotating(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_STATE_HOME", t.TempDir())
+	fp, _ := app.StateFile("history")
+
+	for _, line := range []string{"1\n", "2\n", "3\n", "4\n", "5\n", "6\n", "7\n"} {
+		if err := app.AppendStateFileRotating("history", []byte(line), 4, 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	table := []struct {
+		path     string
+		expected string
+	}{
+		{fp, "7"},
+		{fp + ".1", "5\n6"},
+		{fp + ".2", "3\n4"},
+	}
+	for _, tbl := range table {
+		if s, _ := openFile(tbl.path); s != tbl.expected {
+			t.Errorf("expected %q in %s, but got %q", tbl.expected, tbl.path, s)
+		}
+	}
+	if exists(fp + ".3") {
+		t.Errorf("%s.3 should be removed", fp)
+	}
+
+	for _, line := range []string{"8\n", "9\n"} {
+		if err := app.AppendStateFileRotating("history", []byte(line), 4, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s, _ := openFile(fp); s != "9" {
+		t.Errorf("expected %q, but got %q", "9", s)
+	}
+}
+
+func TestAppAppendStateFileRotatingFileSystem(t *testing.T) {
+	fsys := newMemFS()
+	os.Setenv("XDG_STATE_HOME", t.TempDir())
+	for _, app := range []App{
+		NewApp("test", WithFileSystem(fsys)),
+		NewApp("test", WithFileSystem(fsys), WithRetry(2, time.Millisecond)),
+	} {
+		fp, _ := app.StateFile("history")
+		for _, line := range []string{"1\n", "2\n", "3\n"} {
+			if err := app.AppendStateFileRotating("history", []byte(line), 4, 1); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for p, expected := range map[string]string{fp: "3\n", fp + ".1": "1\n2\n"} {
+			if b, _ := readFile(fsys, p); string(b) != expected {
+				t.Errorf("expected %q in %s, but got %q", expected, p, b)
+			}
+		}
+		if _, err := os.Stat(fp); !os.IsNotExist(err) {
+			t.Error("OS filesystem should not be touched")
+		}
+		if err := fsys.Remove(fp); err != nil {
+			t.Fatal(err)
+		}
+		if err := fsys.Remove(fp + ".1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAppOpenLatestStateFile(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_STATE_HOME", t.TempDir())
+	fp, _ := app.StateFile("log")
+	os.MkdirAll(path(fp, ".."), 0700)
+
+	old := time.Now().Add(-time.Hour)
+	files := []struct {
+		name    string
+		modTime time.Time
+	}{
+		{"log.3", old},
+		{"log.2", old},
+		{"log.02", time.Now()},
+		{"log.x", time.Now()},
+	}
+	for _, f := range files {
+		if err := os.WriteFile(path(fp, "..", f.name), []byte(f.name), 0600); err != nil {
+			t.Fatal(err)
+		}
+		os.Chtimes(path(fp, "..", f.name), f.modTime, f.modTime)
+	}
+
+	table := []struct {
+		create   string
+		expected string
+	}{
+		{"", "log.02"},
+		{"log.1", "log.1"},
+		{"log", "log"},
+	}
+	for _, tbl := range table {
+		if tbl.create != "" {
+			os.WriteFile(path(fp, "..", tbl.create), []byte(tbl.create), 0600)
+		}
+		f, err := app.OpenLatestStateFile("log")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s, _ := openFile(f.Name()); s != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, s)
+		}
+		f.Close()
+	}
+
+	if _, err := app.OpenLatestStateFile("none"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected %v, but got %v", ErrNotFound, err)
+	}
+}
@@ -0,0 +1,119 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// RuntimeDirWithCleanup is like App#RuntimeDir, but also returns cleanup func and whether the directory is fallback.
+//
+// If XDG_RUNTIME_DIR envvar is not defined, app's runtime directory falls back to temporary directory,
+// which is not removed on logout unlike XDG_RUNTIME_DIR. Then fallback is true,
+// and cleanup removes app's runtime directory with its contents, so that app can defer it on shutdown
+// to avoid leaving sockets and pid files in temporary directory.
+// Otherwise cleanup does nothing, because the system manages the directory.
+//
+// If app's name is invalid, cleanup removes nothing and returns the validation error,
+// because the directory could be the base directory that is shared by all apps.
+func (a App) RuntimeDirWithCleanup() (path string, cleanup func() error, fallback bool) {
+	dir := a.RuntimeDir()
+	if err := a.validateName(); err != nil {
+		return dir, func() error { return err }, false
+	}
+	if a.source("RUNTIME_DIR", "XDG_RUNTIME_DIR") != sourceTemp {
+		return dir, func() error { return nil }, false
+	}
+	return dir, func() error {
+		if err := a.osAccess(); err != nil {
+			return err
+		}
+		return os.RemoveAll(dir)
+	}, true
+}
+
+// RequireRuntimeDir is like App#RuntimeDir, but returns ErrRuntimeDirUnset instead of falling back to temporary directory,
+// for apps that must not put sockets and pid files in directory that other users can access.
+func (a App) RequireRuntimeDir() (string, error) {
+	if err := a.validateName(); err != nil {
+		return "", err
+	}
+	if a.source("RUNTIME_DIR", "XDG_RUNTIME_DIR") == sourceTemp {
+		return "", ErrRuntimeDirUnset
+	}
+	return a.RuntimeDir(), nil
+}
+
+// CleanupRuntime removes pid files of dead processes and their sockets from app's runtime directory,
+// which are left when processes crash, and returns removed files.
+//
+// It relies on naming convention that {{name}}.pid file contains decimal process ID of the owner,
+// and socket of the owner is {{name}}.sock in same directory. Pid files that cannot be parsed are left,
+// and so are all files on platforms that cannot check whether process is alive, such as Plan 9.
+// If runtime directory does not exist, does nothing. If app's name is invalid, returns the validation error
+// without touching files, because the directory could be the base directory that is shared by all apps.
+func (a App) CleanupRuntime() (removed []string, err error) {
+	if err := a.osAccess(); err != nil {
+		return nil, err
+	}
+	if err := a.validateName(); err != nil {
+		return nil, err
+	}
+	dir := a.RuntimeDir()
+	entries, err := a.fs().ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".pid")
+		if !ok || !e.Type().IsRegular() {
+			continue
+		}
+		fp := filepath.Join(dir, e.Name())
+		b, err := readFile(a.fs(), fp)
+		if err != nil {
+			return removed, err
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(string(b)))
+		if err != nil || pid <= 0 || processAlive(pid) {
+			continue
+		}
+		sock := filepath.Join(dir, name+".sock")
+		if err := a.fs().Remove(sock); err == nil {
+			removed = append(removed, sock)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return removed, err
+		}
+		if err := a.fs().Remove(fp); err != nil {
+			return removed, err
+		}
+		removed = append(removed, fp)
+	}
+	return removed, nil
+}
+
+// SessionRuntimeDir returns app's runtime directory for current desktop session, so that app can run once per session
+// without colliding with other logins of same user.
+//
+// If XDG_SESSION_ID envvar is defined, returns {{RuntimeDir}}/sessions/{{id}}. Otherwise returns App#RuntimeDir.
+// The directory is created with 0700 permission like App#EnsureRuntimeDir.
+// Session ID must be plain directory name, otherwise returns error that wraps ErrInvalidName.
+func (a App) SessionRuntimeDir() (string, error) {
+	id := strings.TrimSpace(a.getenv("XDG_SESSION_ID"))
+	if id == "" {
+		return a.EnsureRuntimeDir()
+	}
+	if err := a.validateName(); err != nil {
+		return "", err
+	}
+	if err := validateDirName("session id", id); err != nil {
+		return "", err
+	}
+	return a.ensureDir(func() (string, error) { return filepath.Join(a.RuntimeDir(), "sessions", id), nil })
+}
@@ -0,0 +1,185 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestAppRuntimeDirWithCleanup(t *testing.T) {
+	run := t.TempDir()
+	table := []struct {
+		env      []string
+		fallback bool
+	}{
+		{[]string{"XDG_RUNTIME_DIR=" + run}, false},
+		{nil, true},
+	}
+	for _, tbl := range table {
+		app := NewApp("test", WithEnviron(tbl.env))
+		dir, cleanup, fallback := app.RuntimeDirWithCleanup()
+		if dir != app.RuntimeDir() {
+			t.Errorf("expected %s, but got %s", app.RuntimeDir(), dir)
+		}
+		if fallback != tbl.fallback {
+			t.Errorf("expected fallback %v, but got %v", tbl.fallback, fallback)
+		}
+
+		if err := os.MkdirAll(path(dir, "sub"), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := cleanup(); err != nil {
+			t.Error(err)
+		}
+		if exists(dir) != !tbl.fallback {
+			t.Errorf("%s should be removed only if fallback", dir)
+		}
+	}
+}
+
+func TestAppCleanupRuntime(t *testing.T) {
+	run := t.TempDir()
+	app := NewApp("test", WithEnviron([]string{"XDG_RUNTIME_DIR=" + run}))
+	if removed, err := app.CleanupRuntime(); err != nil || len(removed) != 0 {
+		t.Fatalf("missing runtime dir should be ignored, but got %v, %v", removed, err)
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+	dead := cmd.Process.Pid
+	if processAlive(dead) {
+		t.Skip("liveness of process cannot be checked")
+	}
+	files := map[string]string{
+		"dead.pid":    strconv.Itoa(dead) + "\n",
+		"dead.sock":   "",
+		"alive.pid":   strconv.Itoa(os.Getpid()),
+		"alive.sock":  "",
+		"broken.pid":  "not pid",
+		"nosock.pid":  strconv.Itoa(dead),
+		"unknown.txt": strconv.Itoa(dead),
+	}
+	dir := app.RuntimeDir()
+	os.MkdirAll(dir, 0700)
+	for name, content := range files {
+		if err := os.WriteFile(path(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	removed, err := app.CleanupRuntime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{path(dir, "dead.sock"), path(dir, "dead.pid"), path(dir, "nosock.pid")}
+	if !reflect.DeepEqual(removed, expected) {
+		t.Errorf("expected %v, but got %v", expected, removed)
+	}
+	for name := range files {
+		_, err := os.Stat(path(dir, name))
+		if gone := os.IsNotExist(err); gone != (name == "dead.pid" || name == "dead.sock" || name == "nosock.pid") {
+			t.Errorf("%s: unexpected existence %v", name, err)
+		}
+	}
+
+	// Empty name would clean up the base directory of all apps.
+	sock := path(run, "other.sock")
+	for name, content := range map[string]string{"other.pid": strconv.Itoa(dead), "other.sock": ""} {
+		if err := os.WriteFile(path(run, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := NewApp("", WithEnviron([]string{"XDG_RUNTIME_DIR=" + run})).CleanupRuntime(); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName, but got %v", err)
+	}
+	if !exists(sock) {
+		t.Errorf("%s should be left", sock)
+	}
+}
+
+func TestAppSessionRuntimeDir(t *testing.T) {
+	run := t.TempDir()
+	table := []struct {
+		id       string
+		expected string
+	}{
+		{"", path(run, "test")},
+		{"c2", path(run, "test", "sessions", "c2")},
+		{" 3 ", path(run, "test", "sessions", "3")},
+	}
+	for _, tbl := range table {
+		app := NewApp("test", WithEnviron([]string{"XDG_RUNTIME_DIR=" + run, "XDG_SESSION_ID=" + tbl.id}))
+		dir, err := app.SessionRuntimeDir()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dir != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, dir)
+		}
+		if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+			t.Errorf("%s should be created, but got %v", dir, err)
+		}
+	}
+
+	for _, id := range []string{"..", "a/b", "a\x00"} {
+		app := NewApp("test", WithEnviron([]string{"XDG_RUNTIME_DIR=" + run, "XDG_SESSION_ID=" + id}))
+		if _, err := app.SessionRuntimeDir(); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("%q: expected ErrInvalidName, but got %v", id, err)
+		}
+	}
+}
+
+func TestAppRuntimeDirWithCleanupEmptyName(t *testing.T) {
+	run := t.TempDir()
+	other := path(run, "other")
+	if err := os.MkdirAll(other, 0700); err != nil {
+		t.Fatal(err)
+	}
+	app := NewApp("", WithEnviron([]string{"XDG_RUNTIME_DIR=" + run}), WithEnvPrefix("MYAPP"))
+	_, cleanup, _ := app.RuntimeDirWithCleanup()
+	if err := cleanup(); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName, but got %v", err)
+	}
+	if !exists(other) {
+		t.Error("runtime dir of other app should not be removed")
+	}
+
+	// Without XDG_RUNTIME_DIR, the base is shared temporary directory.
+	_, cleanup, fallback := NewApp("", WithEnviron(nil)).RuntimeDirWithCleanup()
+	if fallback {
+		t.Error("invalid app should not report fallback")
+	}
+	if err := cleanup(); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName, but got %v", err)
+	}
+}
+
+func TestAppRequireRuntimeDir(t *testing.T) {
+	table := []struct {
+		env      []string
+		expected string
+		err      error
+	}{
+		{[]string{"XDG_RUNTIME_DIR=r"}, path("r", "test"), nil},
+		{[]string{"MYAPP_RUNTIME_DIR=m"}, "m", nil},
+		{[]string{"TMPDIR=/tmp"}, "", ErrRuntimeDirUnset},
+	}
+	for _, tbl := range table {
+		app := NewApp("test", WithEnviron(tbl.env), WithEnvPrefix("MYAPP"))
+		dir, err := app.RequireRuntimeDir()
+		if !errors.Is(err, tbl.err) {
+			t.Errorf("expected %v, but got %v", tbl.err, err)
+		}
+		if dir != tbl.expected {
+			t.Errorf("expected %q, but got %q", tbl.expected, dir)
+		}
+	}
+	if _, err := NewApp("", WithEnviron([]string{"XDG_RUNTIME_DIR=r"})).RequireRuntimeDir(); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName, but got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package xdgdir
+
+import "os"
+
+// IsSandboxed reports whether the process runs in flatpak or snap sandbox, and the name of the sandbox.
+//
+// 1. If FLATPAK_ID envvar is defined, returns true and "flatpak".
+// 2. If SNAP envvar is defined, returns true and "snap".
+// 3. Otherwise, returns false and "".
+func IsSandboxed() (bool, string) {
+	return sandbox(os.Getenv)
+}
+
+// WithHostPaths makes App resolve host-visible directories when it runs in sandbox.
+//
+// In flatpak, XDG_*_HOME envvars point to ~/.var/app/{{ID}}, so they are ignored and HOME fallback is used.
+// In snap, HOME points to ~/snap/{{Name}}/{{Revision}}, so XDG_*_HOME envvars are ignored
+// and SNAP_REAL_HOME envvar is used as home directory.
+// Outside sandbox, this option has no effect.
+func WithHostPaths() Option {
+	return func(a *App) {
+		a.opts.hostPaths = true
+	}
+}
+
+func sandbox(getenv func(string) string) (bool, string) {
+	if getenv("FLATPAK_ID") != "" {
+		return true, "flatpak"
+	}
+	if getenv("SNAP") != "" {
+		return true, "snap"
+	}
+	return false, ""
+}
+
+// hostEnv returns host-visible value of envvar if it differs from sandboxed value.
+func (a App) hostEnv(key string) (string, bool) {
+	ok, name := sandbox(a.rawGetenv)
+	if !ok {
+		return "", false
+	}
+
+	switch key {
+	case "XDG_CONFIG_HOME", "XDG_DATA_HOME", "XDG_CACHE_HOME", "XDG_STATE_HOME":
+		return "", true
+	case "HOME":
+		if name == "snap" {
+			if home := a.rawGetenv("SNAP_REAL_HOME"); home != "" {
+				return home, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,49 @@
+package xdgdir
+
+import (
+	"os"
+	"testing"
+)
+
+func TestIsSandboxed(t *testing.T) {
+	table := []struct {
+		flatpak  string
+		snap     string
+		expected bool
+		name     string
+	}{
+		{"", "", false, ""},
+		{"org.example.Test", "", true, "flatpak"},
+		{"", "/snap/test/1", true, "snap"},
+	}
+	defer os.Unsetenv("FLATPAK_ID")
+	defer os.Unsetenv("SNAP")
+	for _, tbl := range table {
+		os.Setenv("FLATPAK_ID", tbl.flatpak)
+		os.Setenv("SNAP", tbl.snap)
+		ok, name := IsSandboxed()
+		if ok != tbl.expected || name != tbl.name {
+			t.Errorf("expected %v %s, but got %v %s", tbl.expected, tbl.name, ok, name)
+		}
+	}
+}
+
+func TestAppWithHostPaths(t *testing.T) {
+	table := []struct {
+		env      []string
+		expected string
+	}{
+		{[]string{"XDG_CONFIG_HOME=/home/u/.var/app/org.example.Test/config", "HOME=/home/u"}, path("/home", "u", ".var", "app", "org.example.Test", "config", "test")},
+		{[]string{"FLATPAK_ID=org.example.Test", "XDG_CONFIG_HOME=/home/u/.var/app/org.example.Test/config", "HOME=/home/u"}, path("/home", "u", ".config", "test")},
+		{[]string{"SNAP=/snap/test/1", "XDG_CONFIG_HOME=/home/u/snap/test/1/.config", "HOME=/home/u/snap/test/1", "SNAP_REAL_HOME=/home/u"}, path("/home", "u", ".config", "test")},
+	}
+	for _, tbl := range table {
+		dir, err := NewApp("test", WithEnviron(tbl.env), WithHostPaths()).ConfigDir()
+		if err != nil {
+			t.Error(err)
+		}
+		if dir != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, dir)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package xdgdir
+
+import "os"
+
+// ScopedEnv sets given envvars on the process, and returns restore func that reverts them to previous values,
+// unsetting those that were not defined before. This is for tests, examples and integration harnesses:
+//
+//	defer xdgdir.ScopedEnv(map[string]string{"XDG_CONFIG_HOME": dir})()
+//
+// ScopedEnv mutates the process environment, so it is not goroutine-safe and must not be used by parallel tests.
+// Prefer WithEnviron option for them.
+func ScopedEnv(overrides map[string]string) (restore func()) {
+	type saved struct {
+		value string
+		ok    bool
+	}
+	prev := make(map[string]saved, len(overrides))
+	for k, v := range overrides {
+		old, ok := os.LookupEnv(k)
+		prev[k] = saved{old, ok}
+		os.Setenv(k, v)
+	}
+	return func() {
+		for k, s := range prev {
+			if s.ok {
+				os.Setenv(k, s.value)
+			} else {
+				os.Unsetenv(k)
+			}
+		}
+	}
+}
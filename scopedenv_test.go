@@ -0,0 +1,27 @@
+package xdgdir
+
+import (
+	"os"
+	"testing"
+)
+
+func TestScopedEnv(t *testing.T) {
+	os.Setenv("XDG_CONFIG_HOME", "a")
+	os.Unsetenv("XDGDIR_TEST_UNSET")
+
+	restore := ScopedEnv(map[string]string{"XDG_CONFIG_HOME": "b", "XDGDIR_TEST_UNSET": "c"})
+	if dir, _ := NewApp("test").ConfigDir(); dir != path("b", "test") {
+		t.Errorf("expected %s, but got %s", path("b", "test"), dir)
+	}
+	if v := os.Getenv("XDGDIR_TEST_UNSET"); v != "c" {
+		t.Errorf("expected c, but got %s", v)
+	}
+
+	restore()
+	if v := os.Getenv("XDG_CONFIG_HOME"); v != "a" {
+		t.Errorf("expected a, but got %s", v)
+	}
+	if _, ok := os.LookupEnv("XDGDIR_TEST_UNSET"); ok {
+		t.Error("XDGDIR_TEST_UNSET should be unset")
+	}
+}
@@ -0,0 +1,187 @@
+package xdgdir
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Origin is where search directory comes from.
+type Origin string
+
+const (
+	// OriginUser is user's directory, such as XDG_CONFIG_HOME or its fallback.
+	OriginUser Origin = "user"
+	// OriginSystem is directory that is defined at XDG_CONFIG_DIRS or XDG_DATA_DIRS envvar.
+	OriginSystem Origin = "system"
+	// OriginDefault is default system directory of the spec that is used when XDG_CONFIG_DIRS or XDG_DATA_DIRS envvar is not defined.
+	OriginDefault Origin = "default"
+	// OriginExtra is directory that is added by WithExtraConfigDirs or WithExtraDataDirs option.
+	OriginExtra Origin = "extra"
+)
+
+// defaultSystemDirs are system directories that are used when envvar is not defined.
+var defaultSystemDirs = map[string][]string{
+	"XDG_CONFIG_DIRS": {"/etc/xdg"},
+	"XDG_DATA_DIRS":   {"/usr/local/share", "/usr/share"},
+}
+
+// SearchEntry is directory in search path of app's files.
+type SearchEntry struct {
+	// Path of directory
+	Path string
+	// Origin of directory
+	Origin Origin
+	// Exists reports whether directory exists.
+	Exists bool
+	// Readable reports whether directory can be opened for reading.
+	Readable bool
+}
+
+// ConfigSearchPath returns directories that FindConfigFile searches in, in precedence order.
+//
+// 1. Directory that is returned App#ConfigDir.
+// 2. Directories that are defined at XDG_CONFIG_DIRS envvar, or /etc/xdg/{{AppName}} if it is not defined.
+// On Windows, %ProgramData%\{{AppName}} is used instead of /etc/xdg/{{AppName}}.
+//
+// Directories that do not exist or are not readable are not omitted but flagged.
+func (a App) ConfigSearchPath() []SearchEntry {
+	d, _ := a.ConfigDir()
+	return a.statSearchPath(a.searchPath(d, "XDG_CONFIG_DIRS"))
+}
+
+// DataSearchPath returns directories that FindDataFile searches in, in precedence order.
+//
+// 1. Directory that is returned App#DataDir.
+// 2. Directories that are defined at XDG_DATA_DIRS envvar,
+// or /usr/local/share/{{AppName}} and /usr/share/{{AppName}} if it is not defined.
+// On Windows, %ProgramData%\{{AppName}} is used instead of them.
+//
+// Directories that do not exist or are not readable are not omitted but flagged.
+func (a App) DataSearchPath() []SearchEntry {
+	d, _ := a.DataDir()
+	return a.statSearchPath(a.searchPath(d, "XDG_DATA_DIRS"))
+}
+
+// ConfigDirs returns directories that FindConfigFile searches in, in precedence order.
+//
+// This is same as paths of App#ConfigSearchPath, but does not touch disk.
+func (a App) ConfigDirs() []string {
+	d, _ := a.ConfigDir()
+	return nonEmpty(a.dirsForSearch(d, "XDG_CONFIG_DIRS"))
+}
+
+// DataDirs returns directories that FindDataFile searches in, in precedence order.
+//
+// This is same as paths of App#DataSearchPath, but does not touch disk.
+func (a App) DataDirs() []string {
+	d, _ := a.DataDir()
+	return nonEmpty(a.dirsForSearch(d, "XDG_DATA_DIRS"))
+}
+
+func nonEmpty(dirs []string) []string {
+	result := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if dir != "" {
+			result = append(result, dir)
+		}
+	}
+	return result
+}
+
+func (a App) statSearchPath(entries []SearchEntry) []SearchEntry {
+	result := make([]SearchEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.Path == "" {
+			continue
+		}
+		if _, err := a.fs().Stat(e.Path); err == nil {
+			e.Exists = true
+			if f, err := a.fs().Open(e.Path); err == nil {
+				e.Readable = true
+				f.Close()
+			}
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+func (a App) searchPath(first string, env string) []SearchEntry {
+	if a.validateName() != nil {
+		return nil
+	}
+	if a.opt().userOnly {
+		return []SearchEntry{{Path: first, Origin: OriginUser}}
+	}
+	dirs, origin := a.systemDirs(env)
+	extras := a.opt().extraDirs[env]
+	if env == "XDG_CONFIG_DIRS" {
+		extras = a.extraConfigDirs()
+	}
+
+	entries := make([]SearchEntry, 1, 1+len(dirs)+len(extras))
+	entries[0] = SearchEntry{Path: first, Origin: OriginUser}
+	for _, dir := range dirs {
+		entries = append(entries, SearchEntry{Path: a.tenantPath(filepath.Join(dir, a.CanonicalName())), Origin: origin})
+	}
+	return a.withExtraDirs(entries, extras)
+}
+
+// withExtraDirs adds extra directories to entries.
+// Relative directories and directories that are already in entries are ignored.
+func (a App) withExtraDirs(entries []SearchEntry, extras []string) []SearchEntry {
+	if len(extras) == 0 {
+		return entries
+	}
+
+	seen := make(map[string]bool, len(entries)+len(extras))
+	for _, e := range entries {
+		seen[filepath.Clean(e.Path)] = true
+	}
+	var added []SearchEntry
+	for _, dir := range extras {
+		if !filepath.IsAbs(dir) {
+			a.logf("xdgdir: extra directory %s is ignored because it is not absolute", dir)
+			continue
+		}
+		if seen[filepath.Clean(dir)] {
+			continue
+		}
+		seen[filepath.Clean(dir)] = true
+		added = append(added, SearchEntry{Path: dir, Origin: OriginExtra})
+	}
+
+	if a.opt().prependExtraDirs {
+		return append(added, entries...)
+	}
+	return append(entries, added...)
+}
+
+// systemDirs returns directories that are defined at env, or default directories if it is not defined.
+// Surrounding whitespace of each directory is trimmed, and directories that become empty are dropped.
+// If App is created with WithUserOnly option, returns no directories.
+func (a App) systemDirs(env string) ([]string, Origin) {
+	if a.opt().userOnly {
+		return nil, OriginSystem
+	}
+	v := strings.TrimSpace(a.getenv(env))
+	if v == "" {
+		if a.goos() == "windows" {
+			return nonEmpty([]string{a.getenv("ProgramData")}), OriginDefault
+		}
+		dirs := defaultSystemDirs[env]
+		paths := make([]string, len(dirs))
+		for i, dir := range dirs {
+			paths[i] = filepath.FromSlash(dir)
+		}
+		return paths, OriginDefault
+	}
+	parts := strings.Split(v, string(a.listSeparator()))
+	dirs := parts[:0]
+	for _, dir := range parts {
+		if dir = strings.TrimSpace(dir); dir != "" {
+			dirs = append(dirs, a.anchor(dir))
+		}
+	}
+	return dirs, OriginSystem
+}
@@ -0,0 +1,250 @@
+package xdgdir
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAppConfigSearchPath(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "a"))
+	os.Setenv("XDG_CONFIG_DIRS", join(path("testdata", "z"), path("testdata", "c")))
+
+	expected := []SearchEntry{
+		{path("testdata", "a", "test"), OriginUser, true, true},
+		{path("testdata", "z", "test"), OriginSystem, false, false},
+		{path("testdata", "c", "test"), OriginSystem, true, true},
+	}
+	if entries := app.ConfigSearchPath(); !reflect.DeepEqual(entries, expected) {
+		t.Errorf("expected %v, but got %v", expected, entries)
+	}
+}
+
+func TestAppConfigSearchPathDefault(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "a"))
+	os.Setenv("XDG_CONFIG_DIRS", "")
+
+	entries := app.ConfigSearchPath()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, but got %v", entries)
+	}
+	if e := entries[1]; e.Path != path("/etc", "xdg", "test") || e.Origin != OriginDefault {
+		t.Errorf("expected default /etc/xdg/test, but got %v", e)
+	}
+}
+
+func TestAppConfigDirsWithWhitespace(t *testing.T) {
+	table := []struct {
+		dirs     string
+		expected []string
+	}{
+		{join(" b ", "\tc"), []string{path("a", "test"), path("b", "test"), path("c", "test")}},
+		{join("b", " ", "c"), []string{path("a", "test"), path("b", "test"), path("c", "test")}},
+		{"\t", []string{path("a", "test"), path("/etc", "xdg", "test")}},
+	}
+	for _, tbl := range table {
+		app := NewApp("test", WithEnviron([]string{"XDG_CONFIG_HOME=a", "XDG_CONFIG_DIRS=" + tbl.dirs}), WithGOOS("linux"))
+		if dirs := app.ConfigDirs(); !reflect.DeepEqual(dirs, tbl.expected) {
+			t.Errorf("expected %v, but got %v", tbl.expected, dirs)
+		}
+	}
+}
+
+func TestAppSearchPathProgramData(t *testing.T) {
+	table := []struct {
+		env      []string
+		expected []string
+	}{
+		{[]string{"XDG_CONFIG_HOME=a", "ProgramData=" + path("C:", "ProgramData")}, []string{path("a", "test"), path("C:", "ProgramData", "test")}},
+		{[]string{"XDG_CONFIG_HOME=a", "XDG_CONFIG_DIRS=b", "ProgramData=" + path("C:", "ProgramData")}, []string{path("a", "test"), path("b", "test")}},
+		{[]string{"XDG_CONFIG_HOME=a"}, []string{path("a", "test")}},
+	}
+	for _, tbl := range table {
+		app := NewApp("test", WithEnviron(tbl.env), WithGOOS("windows"))
+		if dirs := app.ConfigDirs(); !reflect.DeepEqual(dirs, tbl.expected) {
+			t.Errorf("expected %v, but got %v", tbl.expected, dirs)
+		}
+	}
+
+	app := NewApp("test", WithEnviron([]string{"XDG_DATA_HOME=a", "ProgramData=c"}), WithGOOS("windows"))
+	if dirs := app.DataDirs(); !reflect.DeepEqual(dirs, []string{path("a", "test"), path("c", "test")}) {
+		t.Errorf("expected ProgramData in data dirs, but got %v", dirs)
+	}
+}
+
+func TestAppDataSearchPath(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_DATA_HOME", path("testdata", "z"))
+	os.Setenv("XDG_DATA_DIRS", "")
+
+	expected := []SearchEntry{
+		{path("testdata", "z", "test"), OriginUser, false, false},
+		{path("/usr", "local", "share", "test"), OriginDefault, false, false},
+		{path("/usr", "share", "test"), OriginDefault, false, false},
+	}
+	entries := app.DataSearchPath()
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %v, but got %v", expected, entries)
+	}
+	for i, e := range entries {
+		if e.Path != expected[i].Path || e.Origin != expected[i].Origin {
+			t.Errorf("expected %v, but got %v", expected[i], e)
+		}
+	}
+	if entries[0].Exists || entries[0].Readable {
+		t.Errorf("%s should be flagged as not existing", entries[0].Path)
+	}
+}
+
+func TestAppSearchPathMatchesFindDirs(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "a"))
+	os.Setenv("XDG_CONFIG_DIRS", join(path("testdata", "b"), path("testdata", "c")))
+
+	d, _ := app.ConfigDir()
+	dirs := app.dirsForSearch(d, "XDG_CONFIG_DIRS")
+	entries := app.ConfigSearchPath()
+	for i, e := range entries {
+		if e.Path != dirs[i] {
+			t.Errorf("expected %s, but got %s", dirs[i], e.Path)
+		}
+	}
+}
+
+func TestAppConfigDirs(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", "a")
+	os.Setenv("XDG_CONFIG_DIRS", join("b", "c"))
+
+	expected := []string{path("a", "test"), path("b", "test"), path("c", "test")}
+	if dirs := app.ConfigDirs(); !reflect.DeepEqual(dirs, expected) {
+		t.Errorf("expected %v, but got %v", expected, dirs)
+	}
+}
+
+func TestAppWithExtraDirs(t *testing.T) {
+	abs := t.TempDir()
+	os.Setenv("XDG_CONFIG_HOME", "a")
+	os.Setenv("XDG_CONFIG_DIRS", abs)
+	os.Setenv("XDG_DATA_HOME", "d")
+	os.Setenv("XDG_DATA_DIRS", "e")
+	extra1 := path(abs, "extra1")
+	extra2 := path(abs, "extra2")
+
+	table := []struct {
+		opts     []Option
+		config   []string
+		data     []string
+		warnings int
+	}{
+		{
+			[]Option{WithExtraConfigDirs(extra1, "relative", extra2, extra1, path(abs, "test"))},
+			[]string{path("a", "test"), path(abs, "test"), extra1, extra2},
+			[]string{path("d", "test"), path("e", "test")},
+			1,
+		},
+		{
+			[]Option{WithExtraConfigDirs(extra1), WithExtraDataDirs(extra2), WithPrependExtraDirs()},
+			[]string{extra1, path("a", "test"), path(abs, "test")},
+			[]string{extra2, path("d", "test"), path("e", "test")},
+			0,
+		},
+	}
+	for _, tbl := range table {
+		warnings := 0
+		app := NewApp("test", append(tbl.opts, WithLogf(func(string, ...interface{}) { warnings++ }))...)
+		if dirs := app.ConfigDirs(); !reflect.DeepEqual(dirs, tbl.config) {
+			t.Errorf("expected %v, but got %v", tbl.config, dirs)
+		}
+		if dirs := app.DataDirs(); !reflect.DeepEqual(dirs, tbl.data) {
+			t.Errorf("expected %v, but got %v", tbl.data, dirs)
+		}
+		if warnings != tbl.warnings {
+			t.Errorf("expected %d warnings, but got %d", tbl.warnings, warnings)
+		}
+	}
+}
+
+func TestAppFindAllConfigFilesWithExtraDirs(t *testing.T) {
+	extra, err := filepath.Abs(path("testdata", "c", "test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := NewApp("test", WithExtraConfigDirs(extra))
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "c"))
+	os.Setenv("XDG_CONFIG_DIRS", path("testdata", "b"))
+
+	files, err := app.FindAllConfigFiles("ccc.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{path("testdata", "c", "test", "ccc.txt"), path(extra, "ccc.txt")}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected %v, but got %v", expected, files)
+	}
+}
+
+func TestAppWithUserOnly(t *testing.T) {
+	env := []string{"XDG_CONFIG_HOME=" + path("testdata", "a"), "XDG_CONFIG_DIRS=" + path("testdata", "c"), "XDG_DATA_HOME=d"}
+	app := NewApp("test", WithEnviron(env), WithUserOnly(), WithExtraConfigDirs(path("/opt", "test")))
+
+	if dirs := app.ConfigDirs(); !reflect.DeepEqual(dirs, []string{path("testdata", "a", "test")}) {
+		t.Errorf("expected only user dir, but got %v", dirs)
+	}
+	if dirs := app.DataDirs(); !reflect.DeepEqual(dirs, []string{path("d", "test")}) {
+		t.Errorf("expected only user dir, but got %v", dirs)
+	}
+	if _, err := app.FindConfigFile("ccc.txt"); err == nil {
+		t.Error("file in system dir should not be found")
+	}
+	if _, err := app.FindConfigFile("aaa.txt"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAppWithRejectSymlinkedSearchDirs(t *testing.T) {
+	dir := t.TempDir()
+	target, err := filepath.Abs(path("testdata", "c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, path(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+	env := []string{"XDG_CONFIG_HOME=" + path("testdata", "a"), "XDG_CONFIG_DIRS=" + join(path(dir, "link"), path("testdata", "b"))}
+
+	if _, err := NewApp("test", WithEnviron(env)).FindConfigFile("ccc.txt"); err != nil {
+		t.Errorf("symlinked dir should be searched by default, but got %v", err)
+	}
+
+	var logs []string
+	logf := func(format string, v ...interface{}) { logs = append(logs, format) }
+	app := NewApp("test", WithEnviron(env), WithRejectSymlinkedSearchDirs(), WithLogf(logf))
+	if _, err := app.FindConfigFile("ccc.txt"); err == nil {
+		t.Error("file in symlinked dir should not be found")
+	}
+	if _, err := app.FindConfigFile("bbb.txt"); err != nil {
+		t.Error(err)
+	}
+	expected := []string{path("testdata", "a", "test"), path("testdata", "b", "test")}
+	if dirs := app.ConfigDirs(); !reflect.DeepEqual(dirs, expected) {
+		t.Errorf("expected %v, but got %v", expected, dirs)
+	}
+	if len(logs) == 0 {
+		t.Error("skipped dir should be logged")
+	}
+
+	// Symlinks are checked on FileSystem of the app, not on the OS filesystem.
+	for _, app := range []App{
+		NewApp("test", WithEnviron(env), WithRejectSymlinkedSearchDirs(), WithFileSystem(newMemFS())),
+		NewApp("test", WithEnviron(env), WithRejectSymlinkedSearchDirs(), WithNoFilesystemAccess()),
+	} {
+		expected := []string{path("testdata", "a", "test"), path(dir, "link", "test"), path("testdata", "b", "test")}
+		if dirs := app.ConfigDirs(); !reflect.DeepEqual(dirs, expected) {
+			t.Errorf("expected %v, but got %v", expected, dirs)
+		}
+	}
+}
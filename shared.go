@@ -0,0 +1,81 @@
+package xdgdir
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+)
+
+// SharedCacheDir is reserved directory name in cache directory that contains cache files shared by all apps.
+// App cannot have this name.
+const SharedCacheDir = ".shared"
+
+// SharedCacheFile returns path of cache file that is shared by all apps and addressed by hash of its content,
+// so that apps can dedupe identical blobs such as downloaded assets.
+//
+// hash is lower case hex encoded SHA-256 digest of the content. Files are sharded by first two characters
+// of hash, like {{CacheDir}}/.shared/ab/abcdef..., where CacheDir is the base directory without app name.
+func SharedCacheFile(hash string) (string, error) {
+	if err := validateHash(hash); err != nil {
+		return "", err
+	}
+	dir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, SharedCacheDir, hash[:2], hash), nil
+}
+
+// WriteSharedCache writes data to shared cache file atomically, and returns its hash.
+// If the file already exists, it is replaced with same content.
+func WriteSharedCache(data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	fp, err := SharedCacheFile(hash)
+	if err != nil {
+		return "", err
+	}
+	// Shared cache is not app's file, so it is written on the OS filesystem without options of any app.
+	if err := (App{}).writeFileAtomic(fp, data); err != nil {
+		return "", err
+	}
+	return hash, nil
+}
+
+// ReadSharedCache reads shared cache file that has given hash, and verifies its content by the hash.
+// If the file does not exist, returns error that wraps ErrNotFound.
+func ReadSharedCache(hash string) ([]byte, error) {
+	fp, err := SharedCacheFile(hash)
+	if err != nil {
+		return nil, err
+	}
+	b, err := readFile(App{}.fs(), fp)
+	if err != nil {
+		return nil, wrapNotExist(err)
+	}
+	sum := sha256.Sum256(b)
+	if want, _ := hex.DecodeString(hash); !bytes.Equal(sum[:], want) {
+		return nil, fmt.Errorf("shared cache file %s is corrupted, its content does not match hash", fp)
+	}
+	return b, nil
+}
+
+// VerifySharedCache reports error if shared cache file that has given hash does not exist or is corrupted.
+func VerifySharedCache(hash string) error {
+	_, err := ReadSharedCache(hash)
+	return err
+}
+
+func validateHash(hash string) error {
+	if len(hash) != sha256.Size*2 {
+		return fmt.Errorf("%w: hash %q is not SHA-256 digest", ErrInvalidName, hash)
+	}
+	for _, c := range hash {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return fmt.Errorf("%w: hash %q is not lower case hex", ErrInvalidName, hash)
+		}
+	}
+	return nil
+}
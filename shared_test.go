@@ -0,0 +1,53 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSharedCache(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+
+	hash, err := WriteSharedCache([]byte("blob"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "fa2c8cc4f28176bbeed4b736df569a34c79cd3723e9ec42f9674b4d46ac6b8b8"; hash != expected {
+		t.Errorf("expected %s, but got %s", expected, hash)
+	}
+	fp, err := SharedCacheFile(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := path(dir, ".shared", "fa", hash); fp != expected {
+		t.Errorf("expected %s, but got %s", expected, fp)
+	}
+	b, err := ReadSharedCache(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "blob" {
+		t.Errorf("expected blob, but got %s", b)
+	}
+
+	if err := os.WriteFile(fp, []byte("tampered"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifySharedCache(hash); err == nil {
+		t.Error("should raise error for corrupted file, but not raised")
+	}
+	if err := VerifySharedCache(strings.Repeat("0", 64)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, but got %v", err)
+	}
+	for _, h := range []string{"", "abc", strings.ToUpper(hash), strings.Repeat("../", 21) + "a"} {
+		if _, err := SharedCacheFile(h); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("%q: expected ErrInvalidName, but got %v", h, err)
+		}
+	}
+	if _, err := NewApp(".shared").CacheDir(); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName for reserved name, but got %v", err)
+	}
+}
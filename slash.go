@@ -0,0 +1,39 @@
+package xdgdir
+
+import "path/filepath"
+
+// ConfigDirSlash returns App#ConfigDir with forward slashes.
+//
+// This is for serialization and display only. Use App#ConfigDir to access files.
+func (a App) ConfigDirSlash() (string, error) {
+	return slashed(a.ConfigDir)
+}
+
+// DataDirSlash returns App#DataDir with forward slashes.
+//
+// This is for serialization and display only. Use App#DataDir to access files.
+func (a App) DataDirSlash() (string, error) {
+	return slashed(a.DataDir)
+}
+
+// CacheDirSlash returns App#CacheDir with forward slashes.
+//
+// This is for serialization and display only. Use App#CacheDir to access files.
+func (a App) CacheDirSlash() (string, error) {
+	return slashed(a.CacheDir)
+}
+
+// RuntimeDirSlash returns App#RuntimeDir with forward slashes.
+//
+// This is for serialization and display only. Use App#RuntimeDir to access files.
+func (a App) RuntimeDirSlash() string {
+	return filepath.ToSlash(a.RuntimeDir())
+}
+
+func slashed(f func() (string, error)) (string, error) {
+	dir, err := f()
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(dir), nil
+}
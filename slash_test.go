@@ -0,0 +1,58 @@
+package xdgdir
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAppDirSlash(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", path("a", "b"))
+	os.Setenv("XDG_DATA_HOME", path("c", "d"))
+	os.Setenv("XDG_CACHE_HOME", path("e", "f"))
+	os.Setenv("XDG_RUNTIME_DIR", path("g", "h"))
+
+	table := []struct {
+		native   func() (string, error)
+		slash    func() (string, error)
+		expected string
+	}{
+		{app.ConfigDir, app.ConfigDirSlash, "a/b/test"},
+		{app.DataDir, app.DataDirSlash, "c/d/test"},
+		{app.CacheDir, app.CacheDirSlash, "e/f/test"},
+		{
+			func() (string, error) { return app.RuntimeDir(), nil },
+			func() (string, error) { return app.RuntimeDirSlash(), nil },
+			"g/h/test",
+		},
+	}
+	for _, tbl := range table {
+		native, _ := tbl.native()
+		s, err := tbl.slash()
+		if err != nil {
+			t.Error(err)
+		}
+		if s != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, s)
+		}
+		if strings.ContainsRune(s, '\\') {
+			t.Errorf("%s should not contain backslash", s)
+		}
+		if rt := filepath.FromSlash(s); rt != native {
+			t.Errorf("expected round trip to %s, but got %s", native, rt)
+		}
+	}
+}
+
+func TestAppDirSlashWithoutHome(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", "")
+	os.Setenv("HOME", "")
+	os.Setenv("USERPROFILE", "")
+
+	if _, err := app.ConfigDirSlash(); err == nil {
+		t.Error("should raise error, but not raised")
+	}
+}
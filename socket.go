@@ -0,0 +1,73 @@
+package xdgdir
+
+import (
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// SocketPath ensures app's runtime directory with 0700 permission, and returns path of Unix socket that has given name
+// in it, for example "control.sock".
+//
+// Returns error if the path does not fit in sun_path of sockaddr_un, which is 108 bytes on Linux and Windows,
+// and 104 bytes on macOS and BSDs, including terminating NUL.
+func (a App) SocketPath(name string) (string, error) {
+	if err := ValidateName(name); err != nil {
+		return "", err
+	}
+	dir, err := a.EnsureRuntimeDir()
+	if err != nil {
+		return "", err
+	}
+	fp := filepath.Join(dir, name)
+	if max := sunPathLen(a.goos()); len(fp) >= max {
+		return "", fmt.Errorf("socket path %s is %d bytes, but must be less than %d bytes", fp, len(fp), max)
+	}
+	return fp, nil
+}
+
+// ListenUnix listens on Unix socket at SocketPath.
+//
+// If socket file already exists but no process accepts connections on it, it is stale socket of crashed process,
+// and is removed before listening. If another process listens on it, returns error.
+// The socket file is removed when the listener is closed.
+func (a App) ListenUnix(name string) (net.Listener, error) {
+	if err := a.osAccess(); err != nil {
+		return nil, err
+	}
+	fp, err := a.SocketPath(name)
+	if err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("unix", fp)
+	if err == nil || !isStaleSocket(fp) {
+		return ln, err
+	}
+	if err := os.Remove(fp); err != nil {
+		return nil, err
+	}
+	return net.Listen("unix", fp)
+}
+
+func isStaleSocket(fp string) bool {
+	fi, err := os.Lstat(fp)
+	if err != nil || fi.Mode()&fs.ModeSocket == 0 {
+		return false
+	}
+	conn, err := net.Dial("unix", fp)
+	if err != nil {
+		return true
+	}
+	conn.Close()
+	return false
+}
+
+func sunPathLen(goos string) int {
+	switch goos {
+	case "darwin", "ios", "dragonfly", "freebsd", "netbsd", "openbsd":
+		return 104
+	}
+	return 108
+}
@@ -0,0 +1,70 @@
+//go:build !plan9
+// +build !plan9
+
+package xdgdir
+
+import (
+	"net"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAppSocketPath(t *testing.T) {
+	dir := shortTempDir(t)
+	app := NewApp("test", WithEnviron([]string{"XDG_RUNTIME_DIR=" + dir}))
+
+	fp, err := app.SocketPath("control.sock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := path(dir, "test", "control.sock"); fp != expected {
+		t.Errorf("expected %s, but got %s", expected, fp)
+	}
+	fi, err := os.Stat(path(dir, "test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fi.IsDir() {
+		t.Errorf("runtime dir should be created")
+	}
+
+	if _, err := app.SocketPath(strings.Repeat("s", 108)); err == nil {
+		t.Error("should raise error for too long path, but not raised")
+	}
+}
+
+func TestAppListenUnix(t *testing.T) {
+	dir := shortTempDir(t)
+	app := NewApp("test", WithEnviron([]string{"XDG_RUNTIME_DIR=" + dir}))
+
+	ln, err := app.ListenUnix("control.sock")
+	if err != nil {
+		t.Skipf("unix socket is not supported: %v", err)
+	}
+	if _, err := app.ListenUnix("control.sock"); err == nil {
+		t.Error("should raise error for socket in use, but not raised")
+	}
+
+	// Leave stale socket file, like crashed process.
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	ln.Close()
+	if _, err := os.Lstat(path(dir, "test", "control.sock")); err != nil {
+		t.Fatal(err)
+	}
+	ln, err = app.ListenUnix("control.sock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln.Close()
+}
+
+// shortTempDir returns temporary directory that has short path, because t.TempDir can be too long for socket path.
+func shortTempDir(t *testing.T) string {
+	dir, err := os.MkdirTemp("", "xdg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
@@ -0,0 +1,28 @@
+package xdgdir
+
+import "fmt"
+
+// FindStateFileOrData finds app's state file that has given name, falling back to data files,
+// and returns the path and the kind of tree where the file is found.
+//
+// This is for apps that moved files like history from data directory to state directory,
+// so that files at old location are still found during transition.
+//
+// 1. Search in directory that is returned App#StateDir.
+// 2. Search in directory that is returned App#DataDir.
+// 3. Search in directories that are defined at XDG_DATA_DIRS envvar.
+func (a App) FindStateFileOrData(names ...string) (string, Kind, error) {
+	if err := a.validateName(); err != nil {
+		return "", 0, err
+	}
+	if d, err := a.StateDir(); err == nil {
+		if fp, err := a.findFile([]string{d}, names...); err == nil {
+			return fp, State, nil
+		}
+	}
+	fp, err := a.FindDataFile(names...)
+	if err != nil {
+		return "", 0, fmt.Errorf("state or data %w", err)
+	}
+	return fp, Data, nil
+}
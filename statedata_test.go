@@ -0,0 +1,41 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestAppFindStateFileOrData(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_STATE_HOME", path("testdata", "a"))
+	os.Setenv("XDG_DATA_HOME", path("testdata", "b"))
+	os.Setenv("XDG_DATA_DIRS", path("testdata", "c"))
+
+	table := []struct {
+		name     string
+		expected string
+		kind     Kind
+		err      error
+	}{
+		{"aaa.txt", path("testdata", "a", "test", "aaa.txt"), State, nil},
+		{"bbb.txt", path("testdata", "b", "test", "bbb.txt"), Data, nil},
+		{"ccc.txt", path("testdata", "c", "test", "ccc.txt"), Data, nil},
+		{"none.txt", "", 0, ErrNotFound},
+	}
+	for _, tbl := range table {
+		f, kind, err := app.FindStateFileOrData(tbl.name)
+		if tbl.err != nil {
+			if !errors.Is(err, tbl.err) {
+				t.Errorf("expected %v, but got %v", tbl.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+		}
+		if f != tbl.expected || kind != tbl.kind {
+			t.Errorf("expected %s in %d, but got %s in %d", tbl.expected, tbl.kind, f, kind)
+		}
+	}
+}
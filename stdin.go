@@ -0,0 +1,13 @@
+package xdgdir
+
+import "io"
+
+// WithStdinConfig returns app whose layered config readers, such as App#LoadLayeredKV, read r as config layer
+// of the highest precedence above App#ConfigDir, for CLIs that accept "--config -" to pipe overrides.
+//
+// r is usually os.Stdin. It is one-shot reader, so it is consumed by the first read and later reads see it empty.
+// The layer has no path, so it is not returned by methods that return paths, such as FindConfigFile.
+// With the layer, layered readers do not fail even if no config file is found.
+func (a App) WithStdinConfig(r io.Reader) App {
+	return a.withOptions(func(o *options) { o.stdinConfig = r })
+}
@@ -0,0 +1,65 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+)
+
+// tenantsDir is directory under app's directories that contains directories of tenants.
+const tenantsDir = "tenants"
+
+// Tenant returns app whose directories are isolated directories of given tenant under app's directories,
+// like $XDG_CONFIG_HOME/{{AppName}}/tenants/{{id}}, for servers that handle multiple tenants.
+//
+// All trees, including search path and runtime directory, are separated for each tenant.
+// id must be plain directory name. Invalid id is not reported here, but by methods that return error,
+// with error that wraps ErrInvalidName.
+func (a App) Tenant(id string) App {
+	return a.withOptions(func(o *options) { o.tenant = &id })
+}
+
+// Tenants returns sorted IDs of tenants that have directory in app's config, data, cache or state directory.
+//
+// If App is derived by App#Tenant, tenants of its parent app are returned.
+// If reading directory fails partway, returns tenants that are found so far with the error.
+func (a App) Tenants() ([]string, error) {
+	base := a.withOptions(func(o *options) { o.tenant = nil })
+	seen := map[string]bool{}
+	var readErr error
+	for _, f := range []func() (string, error){base.ConfigDir, base.DataDir, base.CacheDir, base.StateDir} {
+		dir, err := f()
+		if err != nil {
+			return nil, err
+		}
+		entries, err := a.fs().ReadDir(filepath.Join(dir, tenantsDir))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() && validateDirName("tenant id", e.Name()) == nil {
+				seen[e.Name()] = true
+			}
+		}
+		if err != nil {
+			readErr = err
+			break
+		}
+	}
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, readErr
+}
+
+// tenantPath returns directory of tenant of App#Tenant under app's directory dir.
+// Without tenant, returns dir as is.
+func (a App) tenantPath(dir string) string {
+	if a.opt().tenant == nil {
+		return dir
+	}
+	return filepath.Join(dir, tenantsDir, *a.opt().tenant)
+}
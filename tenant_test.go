@@ -0,0 +1,72 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestAppTenant(t *testing.T) {
+	home := t.TempDir()
+	env := []string{"HOME=" + home, "XDG_CONFIG_DIRS=sys", "XDG_RUNTIME_DIR=run"}
+	app := NewApp("test", WithEnviron(env))
+	acme := app.Tenant("acme")
+
+	dir, err := acme.ConfigDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := path(home, ".config", "test", "tenants", "acme"); dir != expected {
+		t.Errorf("expected %s, but got %s", expected, dir)
+	}
+	expected := []string{dir, path("sys", "test", "tenants", "acme")}
+	if dirs := acme.ConfigDirs(); !reflect.DeepEqual(dirs, expected) {
+		t.Errorf("expected %v, but got %v", expected, dirs)
+	}
+	if dir, expected := acme.RuntimeDir(), path("run", "test", "tenants", "acme"); dir != expected {
+		t.Errorf("expected %s, but got %s", expected, dir)
+	}
+	if dir, _ := app.ConfigDir(); dir != path(home, ".config", "test") {
+		t.Errorf("parent app should not be changed, but got %s", dir)
+	}
+
+	for _, id := range []string{"", ".", "..", "a/b"} {
+		if _, err := app.Tenant(id).DataDir(); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("%q should be invalid, but got %v", id, err)
+		}
+	}
+
+	ids, err := app.Tenants()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("expected no tenants, but got %v", ids)
+	}
+	for _, tenant := range []App{acme, app.Tenant("beta")} {
+		if _, err := tenant.EnsureDataDir(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := app.Tenant("gamma").EnsureCacheDir(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(path(home, ".config", "test"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path(home, ".config", "test", "tenants"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := acme.Tenants(); err == nil {
+		t.Error("should raise error for tenants file, but not raised")
+	}
+	os.Remove(path(home, ".config", "test", "tenants"))
+	ids, err = acme.Tenants()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"acme", "beta", "gamma"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected %v, but got %v", expected, ids)
+	}
+}
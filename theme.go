@@ -0,0 +1,29 @@
+package xdgdir
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// FindThemedResource finds resource that has given name in themed directories like freedesktop icon themes.
+//
+// Unlike FindDataFile, resources are searched in base data directories, not in app's subdirectories.
+// Search order is:
+//
+// 1. {{DataDir}}/{{base}}/{{theme}}/{{name}} for each data directory.
+// 2. {{DataDir}}/{{base}}/hicolor/{{name}} for each data directory.
+// 3. {{DataDir}}/{{base}}/{{name}} for each data directory.
+//
+// Data directories are XDG_DATA_HOME (or its fallback) followed by directories that are defined at XDG_DATA_DIRS envvar.
+func (a App) FindThemedResource(base, theme, name string) (string, error) {
+	home, _ := a.dataHome()
+	sys, _ := a.systemDirs("XDG_DATA_DIRS")
+	dirs := append([]string{home}, sys...)
+
+	for _, sub := range []string{filepath.Join(base, theme), filepath.Join(base, "hicolor"), base} {
+		if f, err := a.findFile(dirs, sub, name); err == nil {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("resource %s in theme %s is %w", name, theme, ErrNotFound)
+}
@@ -0,0 +1,59 @@
+package xdgdir
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppFindThemedResource(t *testing.T) {
+	app := NewApp("test")
+	home := t.TempDir()
+	sys := t.TempDir()
+	os.Setenv("XDG_DATA_HOME", home)
+	os.Setenv("XDG_DATA_DIRS", sys)
+
+	for _, f := range []string{
+		path(sys, "icons", "adwaita", "a.png"),
+		path(home, "icons", "adwaita", "a.png"),
+		path(sys, "icons", "adwaita", "b.png"),
+		path(home, "icons", "hicolor", "b.png"),
+		path(sys, "icons", "hicolor", "c.png"),
+		path(home, "icons", "c.png"),
+		path(sys, "icons", "d.png"),
+	} {
+		if err := os.MkdirAll(filepath.Dir(f), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(f, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	table := []struct {
+		name     string
+		expected string
+		err      bool
+	}{
+		{"a.png", path(home, "icons", "adwaita", "a.png"), false},
+		{"b.png", path(sys, "icons", "adwaita", "b.png"), false},
+		{"c.png", path(sys, "icons", "hicolor", "c.png"), false},
+		{"d.png", path(sys, "icons", "d.png"), false},
+		{"z.png", "", true},
+	}
+	for _, tbl := range table {
+		f, err := app.FindThemedResource("icons", "adwaita", tbl.name)
+		if tbl.err {
+			if err == nil {
+				t.Error("should raise error, but not raised")
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+		}
+		if f != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, f)
+		}
+	}
+}
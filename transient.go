@@ -0,0 +1,18 @@
+//go:build !windows && !plan9 && !js
+// +build !windows,!plan9,!js
+
+package xdgdir
+
+import (
+	"errors"
+	"syscall"
+)
+
+func isTransient(err error) bool {
+	for _, errno := range []syscall.Errno{syscall.EINTR, syscall.EAGAIN, syscall.EBUSY, syscall.ETXTBSY} {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	return false
+}
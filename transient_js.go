@@ -0,0 +1,16 @@
+package xdgdir
+
+import (
+	"errors"
+	"syscall"
+)
+
+// isTransient is like that of Unix, but js has no ETXTBSY.
+func isTransient(err error) bool {
+	for _, errno := range []syscall.Errno{syscall.EINTR, syscall.EAGAIN, syscall.EBUSY} {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	return false
+}
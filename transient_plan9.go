@@ -0,0 +1,6 @@
+package xdgdir
+
+// isTransient always reports false, because Plan 9 errors are strings that cannot be classified reliably.
+func isTransient(err error) bool {
+	return false
+}
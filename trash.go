@@ -0,0 +1,96 @@
+package xdgdir
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// TrashDir returns user's trash directory of freedesktop trash specification, that is shared by all apps.
+//
+// 1. If XDG_DATA_HOME envvar is defined, returns $XDG_DATA_HOME/Trash.
+// 2. IF HOME envvar is defined, returns $HOME/.local/share/Trash
+// 3. IF USERPROFILE envvar is defined, returns $USERPROFILE/.local/share/Trash (for Windows)
+func TrashDir() (string, error) {
+	dir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "Trash"), nil
+}
+
+// TrashFile moves src into TrashDir, with .trashinfo file that records original path and deletion date,
+// so that file managers can restore it.
+//
+// If the trash already has file or .trashinfo with same name, counter is appended to the name like "file.2.txt".
+// src must be on same filesystem as the trash, because it is moved by os.Rename.
+func TrashFile(src string) error {
+	dir, err := TrashDir()
+	if err != nil {
+		return err
+	}
+	return trashFile(dir, src, time.Now())
+}
+
+func trashFile(dir string, src string, now time.Time) error {
+	src, err := filepath.Abs(src)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Lstat(src); err != nil {
+		return wrapNotExist(err)
+	}
+	files, info := filepath.Join(dir, "files"), filepath.Join(dir, "info")
+	for _, d := range []string{files, info} {
+		if err := os.MkdirAll(d, 0700); err != nil {
+			return err
+		}
+	}
+
+	content := fmt.Sprintf("[Trash Info]\nPath=%s\nDeletionDate=%s\n",
+		(&url.URL{Path: filepath.ToSlash(src)}).EscapedPath(), now.Format("2006-01-02T15:04:05"))
+	base := filepath.Base(src)
+	ext := filepath.Ext(base)
+	stem := strings.TrimSuffix(base, ext)
+	for n := 1; ; n++ {
+		name := base
+		if n > 1 {
+			name = fmt.Sprintf("%s.%d%s", stem, n, ext)
+		}
+		// Creating .trashinfo exclusively reserves the name, as required by the specification.
+		infoPath := filepath.Join(info, name+".trashinfo")
+		f, err := os.OpenFile(infoPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		// Trashed file whose .trashinfo is lost, for example after crash, must not be replaced by rename.
+		dst := filepath.Join(files, name)
+		if _, err := os.Lstat(dst); !errors.Is(err, fs.ErrNotExist) {
+			f.Close()
+			os.Remove(infoPath)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		_, err = f.WriteString(content)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err == nil {
+			err = os.Rename(src, dst)
+		}
+		if err != nil {
+			os.Remove(infoPath)
+		}
+		return err
+	}
+}
@@ -0,0 +1,83 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTrashFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", filepath.Join(dir, "data"))
+	trash, err := TrashDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := filepath.Join(dir, "data", "Trash"); trash != expected {
+		t.Errorf("expected %s, but got %s", expected, trash)
+	}
+
+	now := time.Date(2004, 8, 31, 22, 32, 8, 0, time.Local)
+	src := filepath.Join(dir, "my file.txt")
+	table := []string{"my file.txt", "my file.2.txt", "my file.3.txt"}
+	for _, expected := range table {
+		if err := os.WriteFile(src, []byte(expected), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := trashFile(trash, src, now); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(src); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s should be moved, but got %v", src, err)
+		}
+		content, err := os.ReadFile(filepath.Join(trash, "files", expected))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != expected {
+			t.Errorf("expected %s, but got %s", expected, content)
+		}
+		info, err := os.ReadFile(filepath.Join(trash, "info", expected+".trashinfo"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectedInfo := "[Trash Info]\nPath=" + filepath.ToSlash(dir) + "/my%20file.txt\nDeletionDate=2004-08-31T22:32:08\n"
+		if string(info) != expectedInfo {
+			t.Errorf("expected %q, but got %q", expectedInfo, info)
+		}
+	}
+
+	if err := trashFile(trash, src, now); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, but got %v", err)
+	}
+}
+
+func TestTrashFileOrphan(t *testing.T) {
+	trash := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(trash, "files"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	// Trashed file whose .trashinfo is lost.
+	orphan := filepath.Join(trash, "files", "a.txt")
+	if err := os.WriteFile(orphan, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	src := filepath.Join(t.TempDir(), "a.txt")
+	if err := os.WriteFile(src, []byte("new"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := trashFile(trash, src, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	for name, expected := range map[string]string{"a.txt": "old", "a.2.txt": "new"} {
+		if b, _ := os.ReadFile(filepath.Join(trash, "files", name)); string(b) != expected {
+			t.Errorf("expected %q in %s, but got %q", expected, name, b)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(trash, "info", "a.txt.trashinfo")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("info of orphan should not be created, but got %v", err)
+	}
+}
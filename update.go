@@ -0,0 +1,26 @@
+package xdgdir
+
+import "errors"
+
+// ConfigFileForUpdate returns path of app's config file that has given name to read and then write back.
+//
+// 1. If the file exists in config search path, returns path of the one that has the highest precedence and true.
+// 2. Otherwise, returns path in directory that is returned App#ConfigDir and false.
+//
+// Note that existing file may be in system directory such as /etc/xdg/{{AppName}}, which is usually not writable
+// and should not be modified by app. To save user's changes there, write to App#ConfigFile instead,
+// which then shadows the system file.
+func (a App) ConfigFileForUpdate(name string) (path string, existed bool, err error) {
+	fp, err := a.FindConfigFile(name)
+	if err == nil {
+		return fp, true, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return "", false, err
+	}
+	fp, err = a.ConfigFile(name)
+	if err != nil {
+		return "", false, err
+	}
+	return fp, false, nil
+}
@@ -0,0 +1,39 @@
+package xdgdir
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAppConfigFileForUpdate(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "a"))
+	os.Setenv("XDG_CONFIG_DIRS", path("testdata", "b"))
+
+	table := []struct {
+		name     string
+		expected string
+		existed  bool
+		err      bool
+	}{
+		{"aaa.txt", path("testdata", "a", "test", "aaa.txt"), true, false},
+		{"bbb.txt", path("testdata", "b", "test", "bbb.txt"), true, false},
+		{"none.txt", path("testdata", "a", "test", "none.txt"), false, false},
+		{"none\x00", "", false, true},
+	}
+	for _, tbl := range table {
+		f, existed, err := app.ConfigFileForUpdate(tbl.name)
+		if tbl.err {
+			if err == nil {
+				t.Errorf("should raise error for %q, but not raised", tbl.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+		}
+		if f != tbl.expected || existed != tbl.existed {
+			t.Errorf("expected %s %v, but got %s %v", tbl.expected, tbl.existed, f, existed)
+		}
+	}
+}
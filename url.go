@@ -0,0 +1,43 @@
+package xdgdir
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ResolveURL returns file path that is referenced by URL-like string such as "xdg-config://myapp/config.toml".
+//
+// Scheme selects the tree: "xdg-config", "xdg-data", "xdg-cache", "xdg-state" or "xdg-runtime".
+// Host is app name that must be plain directory name, and path is file name in app's directory, that is resolved like App#Resolve.
+// Percent-encoded characters are decoded. Query, fragment and user info are rejected.
+func ResolveURL(s string) (string, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	name, ok := strings.CutPrefix(u.Scheme, "xdg-")
+	if !ok {
+		return "", fmt.Errorf("unknown scheme %q in %s", u.Scheme, s)
+	}
+	kind, err := ParseKind(name)
+	if err != nil || kind == Bin {
+		return "", fmt.Errorf("unknown scheme %q in %s", u.Scheme, s)
+	}
+	if u.Opaque != "" || u.User != nil || u.RawQuery != "" || u.Fragment != "" || u.Port() != "" {
+		return "", fmt.Errorf("%s must have only scheme, app name and file name", s)
+	}
+	if err := validateDirName("app name", u.Host); err != nil {
+		return "", fmt.Errorf("%w in %s", err, s)
+	}
+	file := strings.TrimPrefix(u.Path, "/")
+	if file == "" {
+		return "", fmt.Errorf("%w: file name is empty in %s", ErrInvalidName, s)
+	}
+	for _, seg := range strings.Split(file, "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			return "", fmt.Errorf("%w: file name %q in %s must not have empty, . or .. segment", ErrInvalidName, file, s)
+		}
+	}
+	return NewApp(u.Host).Resolve(kind, file)
+}
@@ -0,0 +1,55 @@
+package xdgdir
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestResolveURL(t *testing.T) {
+	os.Setenv("XDG_CONFIG_HOME", "a")
+	os.Setenv("XDG_DATA_HOME", "b")
+	os.Setenv("XDG_CACHE_HOME", "c")
+	os.Setenv("XDG_STATE_HOME", "d")
+	os.Setenv("XDG_RUNTIME_DIR", "e")
+
+	table := []struct {
+		s        string
+		expected string
+	}{
+		{"xdg-config://myapp/config.toml", path("a", "myapp", "config.toml")},
+		{"xdg-data://myapp/themes/dark%20mode.css", path("b", "myapp", "themes", "dark mode.css")},
+		{"xdg-cache://myapp/index.db", path("c", "myapp", "index.db")},
+		{"XDG-State://myapp/history", path("d", "myapp", "history")},
+		{"xdg-runtime://myapp/control.sock", path("e", "myapp", "control.sock")},
+	}
+	for _, tbl := range table {
+		actual, err := ResolveURL(tbl.s)
+		if err != nil {
+			t.Errorf("%s: %v", tbl.s, err)
+		}
+		if actual != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, actual)
+		}
+	}
+
+	for _, s := range []string{
+		"https://myapp/config.toml",
+		"xdg-bin://myapp/tool",
+		"xdg-home://myapp/config.toml",
+		"xdg-config://myapp/config.toml?x=1",
+		"xdg-config://user@myapp/config.toml",
+		"xdg-config:myapp/config.toml",
+		"config.toml",
+	} {
+		if _, err := ResolveURL(s); err == nil {
+			t.Errorf("%s should be rejected, but not rejected", s)
+		}
+	}
+	for _, s := range []string{"xdg-config:///config.toml", "xdg-config://myapp", "xdg-config://myapp/../other/config.toml", "xdg-config://myapp/a//b",
+		"xdg-config://../x.conf", "xdg-config://./x.conf", "xdg-runtime://../x.sock", "xdg-runtime://./x.sock"} {
+		if _, err := ResolveURL(s); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("%s: expected ErrInvalidName, but got %v", s, err)
+		}
+	}
+}
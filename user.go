@@ -0,0 +1,27 @@
+package xdgdir
+
+import "os/user"
+
+// AppForUser returns new app object that resolves directories of given user instead of the process user.
+//
+// This is same as NewApp(name, append(opts, WithUser(u))...).
+func AppForUser(u *user.User, name string, opts ...Option) App {
+	return NewApp(name, append(opts, WithUser(u))...)
+}
+
+// WithUser makes App resolve directories of given user, for example when service running as root
+// sets up files of another user.
+//
+// HOME and USERPROFILE are taken from u.HomeDir, and u.Uid is used for runtime directory fallback.
+// XDG envvars of the process are not consulted in this mode, because they belong to the process user.
+// Note that files and directories created by App are owned by the process user,
+// so caller is responsible for changing their ownership to u.
+func WithUser(u *user.User) Option {
+	return func(a *App) {
+		a.opts.env = map[string]string{
+			"HOME":        u.HomeDir,
+			"USERPROFILE": u.HomeDir,
+		}
+		a.opts.uid = u.Uid
+	}
+}
@@ -0,0 +1,34 @@
+package xdgdir
+
+import (
+	"os"
+	"os/user"
+	"testing"
+)
+
+func TestAppForUser(t *testing.T) {
+	u := &user.User{Uid: "1234", Username: "alice", HomeDir: path("/home", "alice")}
+	os.Setenv("XDG_CONFIG_HOME", "x")
+	os.Setenv("XDG_RUNTIME_DIR", "x")
+	app := AppForUser(u, "test")
+
+	table := []struct {
+		f        func() (string, error)
+		expected string
+	}{
+		{app.ConfigDir, path("/home", "alice", ".config", "test")},
+		{app.DataDir, path("/home", "alice", ".local", "share", "test")},
+		{app.CacheDir, path("/home", "alice", ".cache", "test")},
+		{app.StateDir, path("/home", "alice", ".local", "state", "test")},
+		{func() (string, error) { return app.RuntimeDir(), nil }, path(os.TempDir(), "1234", "test")},
+	}
+	for _, tbl := range table {
+		dir, err := tbl.f()
+		if err != nil {
+			t.Error(err)
+		}
+		if dir != tbl.expected {
+			t.Errorf("expected %s, but got %s", tbl.expected, dir)
+		}
+	}
+}
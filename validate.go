@@ -0,0 +1,36 @@
+package xdgdir
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// Validate checks that app's config, data, cache and state directories are pairwise distinct.
+//
+// If XDG_CONFIG_HOME and XDG_DATA_HOME point to the same directory for example,
+// files of different kinds collide silently. Validate returns error in such case.
+func (a App) Validate() error {
+	dirs := []struct {
+		kind string
+		f    func() (string, error)
+	}{
+		{"config", a.ConfigDir},
+		{"data", a.DataDir},
+		{"cache", a.CacheDir},
+		{"state", a.StateDir},
+	}
+
+	seen := make(map[string]string, len(dirs))
+	for _, d := range dirs {
+		dir, err := d.f()
+		if err != nil {
+			return err
+		}
+		dir = filepath.Clean(dir)
+		if kind, ok := seen[dir]; ok {
+			return fmt.Errorf("%s dir and %s dir are same directory %s", kind, d.kind, dir)
+		}
+		seen[dir] = d.kind
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package xdgdir
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAppValidate(t *testing.T) {
+	app := NewApp("test")
+	table := []struct {
+		configHome string
+		dataHome   string
+		cacheHome  string
+		err        bool
+	}{
+		{"a", "b", "c", false},
+		{"", "", "", false},
+		{"a", "a", "c", true},
+		{"a", "b", "a", true},
+		{"a", "b", "b/", true},
+	}
+
+	os.Setenv("HOME", "h")
+	os.Setenv("XDG_STATE_HOME", "s")
+	for _, tbl := range table {
+		os.Setenv("XDG_CONFIG_HOME", tbl.configHome)
+		os.Setenv("XDG_DATA_HOME", tbl.dataHome)
+		os.Setenv("XDG_CACHE_HOME", tbl.cacheHome)
+		err := app.Validate()
+		if tbl.err && err == nil {
+			t.Errorf("should raise error for %v, but not raised", tbl)
+		}
+		if !tbl.err && err != nil {
+			t.Error(err)
+		}
+	}
+}
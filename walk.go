@@ -0,0 +1,55 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+)
+
+// AllConfigFiles walks all directories in config search path, and calls fn for each regular file in them.
+//
+// layer is the precedence of directory that contains the file: 0 is App#ConfigDir,
+// and higher layers are directories of XDG_CONFIG_DIRS that have lower precedence.
+// Layers are walked in precedence order, and files in each layer are walked in lexical order.
+// Missing directories are skipped, and symlinks are neither followed nor reported.
+//
+// If fn returns fs.SkipDir, remaining files in the directory that contains the file are skipped.
+// If fn returns other error, walking stops and the error is returned.
+//
+// If reading directory fails partway, for example on flaky network filesystem, fn is still called for entries
+// that are read so far, and then walking stops and the read error is returned.
+func (a App) AllConfigFiles(fn func(path string, layer int, d fs.DirEntry) error) error {
+	d, _ := a.ConfigDir()
+	for layer, dir := range a.dirsForSearch(d, "XDG_CONFIG_DIRS") {
+		if dir == "" {
+			continue
+		}
+		if err := a.walkFiles(dir, layer, fn); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a App) walkFiles(dir string, layer int, fn func(path string, layer int, d fs.DirEntry) error) error {
+	// Entries that are read before error are walked, and then the error is returned.
+	entries, readErr := a.fs().ReadDir(dir)
+	for _, e := range entries {
+		fp := filepath.Join(dir, e.Name())
+		switch {
+		case e.IsDir():
+			if err := a.walkFiles(fp, layer, fn); err != nil {
+				return err
+			}
+		case e.Type().IsRegular():
+			err := fn(fp, layer, e)
+			if err == fs.SkipDir {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return readErr
+}
@@ -0,0 +1,47 @@
+package xdgdir
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestAppAllConfigFiles(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", path("testdata", "a"))
+	os.Setenv("XDG_CONFIG_DIRS", join(path("testdata", "x"), path("testdata", "c"), path("testdata", "b")))
+
+	type file struct {
+		path  string
+		layer int
+	}
+	var files []file
+	err := app.AllConfigFiles(func(p string, layer int, d fs.DirEntry) error {
+		files = append(files, file{p, layer})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []file{
+		{path("testdata", "a", "test", "aaa.txt"), 0},
+		{path("testdata", "c", "test", "ccc.txt"), 2},
+		{path("testdata", "c", "test", "d", "ddd.txt"), 2},
+		{path("testdata", "b", "test", "bbb.txt"), 3},
+	}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected %v, but got %v", expected, files)
+	}
+
+	stop := errors.New("stop")
+	var n int
+	err = app.AllConfigFiles(func(string, int, fs.DirEntry) error {
+		n++
+		return stop
+	})
+	if err != stop || n != 1 {
+		t.Errorf("expected to stop with %v after 1 file, but got %v after %d files", stop, err, n)
+	}
+}
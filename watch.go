@@ -0,0 +1,165 @@
+package xdgdir
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// defaultWatchInterval is polling interval of App#WatchConfigDir without WithWatchInterval option.
+const defaultWatchInterval = time.Second
+
+// EventOp is kind of change of Event.
+type EventOp int
+
+const (
+	// EventCreate is file that is created.
+	EventCreate EventOp = iota
+	// EventModify is file whose size or modification time is changed.
+	EventModify
+	// EventRemove is file that is removed.
+	EventRemove
+)
+
+// String returns "create", "modify" or "remove".
+func (op EventOp) String() string {
+	switch op {
+	case EventCreate:
+		return "create"
+	case EventModify:
+		return "modify"
+	case EventRemove:
+		return "remove"
+	}
+	return fmt.Sprintf("EventOp(%d)", int(op))
+}
+
+// Event is change of file in watched directory.
+type Event struct {
+	// Name is slash separated path of the file relative to watched directory.
+	Name string
+	Op   EventOp
+}
+
+// WatchConfigDir watches regular files in App#ConfigDir and its subdirectories, and sends their changes
+// to returned channel, until ctx is done. Then the channel is closed.
+//
+// The directory is polled at interval of WithWatchInterval option, which is 1 second by default,
+// so that it works on any platform and filesystem without OS specific notification.
+// Subdirectories are watched recursively, including ones that are created later, up to depth of WithWatchDepth option.
+// Events that are found in same poll are sent in order of name. Watching does not require the directory
+// to exist: if it is created later, its files are reported as created.
+func (a App) WatchConfigDir(ctx context.Context) (<-chan Event, error) {
+	dir, err := a.ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	prev, err := a.snapshot(dir)
+	if err != nil {
+		return nil, err
+	}
+	interval := a.opt().watchInterval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+			cur, err := a.snapshot(dir)
+			if err != nil {
+				a.logf("xdgdir: failed to poll %s: %v", dir, err)
+				continue
+			}
+			for _, ev := range diffSnapshots(prev, cur) {
+				select {
+				case ch <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+			prev = cur
+		}
+	}()
+	return ch, nil
+}
+
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// snapshot returns stamps of regular files under dir by slash separated relative path.
+func (a App) snapshot(dir string) (map[string]fileStamp, error) {
+	files := map[string]fileStamp{}
+	err := a.snapshotDir(dir, "", 0, files)
+	if errors.Is(err, fs.ErrNotExist) {
+		return files, nil
+	}
+	return files, err
+}
+
+func (a App) snapshotDir(dir, rel string, depth int, files map[string]fileStamp) error {
+	entries, err := a.fs().ReadDir(filepath.Join(dir, filepath.FromSlash(rel)))
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if rel != "" {
+			name = rel + "/" + name
+		}
+		switch {
+		case e.IsDir():
+			if a.opt().watchDepthLimited && depth >= a.opt().watchDepth {
+				continue
+			}
+			// Subdirectory may be removed while walking.
+			if err := a.snapshotDir(dir, name, depth+1, files); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		case e.Type().IsRegular():
+			fi, err := e.Info()
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			files[name] = fileStamp{fi.Size(), fi.ModTime()}
+		}
+	}
+	return nil
+}
+
+func diffSnapshots(prev, cur map[string]fileStamp) []Event {
+	var events []Event
+	for name, s := range cur {
+		p, ok := prev[name]
+		switch {
+		case !ok:
+			events = append(events, Event{name, EventCreate})
+		case p.size != s.size || !p.modTime.Equal(s.modTime):
+			events = append(events, Event{name, EventModify})
+		}
+	}
+	for name := range prev {
+		if _, ok := cur[name]; !ok {
+			events = append(events, Event{name, EventRemove})
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Name < events[j].Name })
+	return events
+}
@@ -0,0 +1,77 @@
+package xdgdir
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestAppWatchConfigDir(t *testing.T) {
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	app := NewApp("test", WithEnviron([]string{"XDG_CONFIG_HOME=" + dir}), WithWatchInterval(10*time.Millisecond))
+	ch, err := app.WatchConfigDir(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shallow, err := NewApp("test", WithEnviron([]string{"XDG_CONFIG_HOME=" + dir}), WithWatchInterval(10*time.Millisecond), WithWatchDepth(0)).WatchConfigDir(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next := func(ch <-chan Event) Event {
+		t.Helper()
+		select {
+		case ev := <-ch:
+			return ev
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting event")
+		}
+		return Event{}
+	}
+	table := []struct {
+		change   func() error
+		expected Event
+		shallow  bool
+	}{
+		{func() error { return os.MkdirAll(path(dir, "test"), 0700) }, Event{}, false},
+		{func() error { return os.WriteFile(path(dir, "test", "a.toml"), []byte("a"), 0600) }, Event{"a.toml", EventCreate}, true},
+		{func() error { return os.WriteFile(path(dir, "test", "a.toml"), []byte("aa"), 0600) }, Event{"a.toml", EventModify}, true},
+		{func() error {
+			os.MkdirAll(path(dir, "test", "themes", "dark"), 0700)
+			return os.WriteFile(path(dir, "test", "themes", "dark", "b.css"), nil, 0600)
+		}, Event{"themes/dark/b.css", EventCreate}, false},
+		{func() error { return os.RemoveAll(path(dir, "test", "themes")) }, Event{"themes/dark/b.css", EventRemove}, false},
+		{func() error { return os.Remove(path(dir, "test", "a.toml")) }, Event{"a.toml", EventRemove}, true},
+	}
+	for _, tbl := range table {
+		if err := tbl.change(); err != nil {
+			t.Fatal(err)
+		}
+		if tbl.expected == (Event{}) {
+			continue
+		}
+		if ev := next(ch); ev != tbl.expected {
+			t.Errorf("expected %v, but got %v", tbl.expected, ev)
+		}
+		if tbl.shallow {
+			if ev := next(shallow); ev != tbl.expected {
+				t.Errorf("expected %v, but got %v", tbl.expected, ev)
+			}
+		}
+	}
+
+	cancel()
+	for range ch {
+	}
+	select {
+	case ev, ok := <-shallow:
+		if ok {
+			t.Errorf("files in subdirectory should not be watched with depth 0, but got %v", ev)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("channel should be closed")
+	}
+}
@@ -0,0 +1,27 @@
+package xdgdir
+
+// WriteConfigFile writes data to app's config file that has given name atomically.
+//
+// Parent directories are created with 0700 permission if they do not exist.
+// Data is written to temporary file that is renamed to the config file after fsync,
+// and the containing directory is fsynced after the rename to make it durable.
+func (a App) WriteConfigFile(name string, data []byte) error {
+	fp, err := a.ConfigFile(name)
+	if err != nil {
+		return err
+	}
+	return a.writeFileAtomic(fp, data)
+}
+
+// WriteDataFile writes data to app's data file that has given name atomically.
+//
+// Parent directories are created with 0700 permission if they do not exist.
+// Data is written to temporary file that is renamed to the data file after fsync,
+// and the containing directory is fsynced after the rename to make it durable.
+func (a App) WriteDataFile(name string, data []byte) error {
+	fp, err := a.DataFile(name)
+	if err != nil {
+		return err
+	}
+	return a.writeFileAtomic(fp, data)
+}
@@ -0,0 +1,77 @@
+package xdgdir
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAppWriteConfigFile(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	for _, s := range []string{"a", "b"} {
+		if err := app.WriteConfigFile("config.txt", []byte(s)); err != nil {
+			t.Fatal(err)
+		}
+		fp, _ := app.ConfigFile("config.txt")
+		if c, _ := openFile(fp); c != s {
+			t.Errorf("expected %s, but got %s", s, c)
+		}
+	}
+
+	dir, _ := app.ConfigDir()
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("temporary files should not be left, but got %d entries", len(entries))
+	}
+}
+
+func TestAppWriteDataFile(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	if err := app.WriteDataFile("data.txt", []byte("a")); err != nil {
+		t.Fatal(err)
+	}
+	fp, _ := app.DataFile("data.txt")
+	if c, _ := openFile(fp); c != "a" {
+		t.Errorf("expected a, but got %s", c)
+	}
+	fi, err := os.Stat(fp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0600 {
+		t.Errorf("expected 0600, but got %o", fi.Mode().Perm())
+	}
+}
+
+func TestAppWriteFileWithoutHome(t *testing.T) {
+	app := NewApp("test")
+	os.Setenv("XDG_CONFIG_HOME", "")
+	os.Setenv("XDG_DATA_HOME", "")
+	os.Setenv("HOME", "")
+	os.Setenv("USERPROFILE", "")
+
+	if err := app.WriteConfigFile("config.txt", nil); err == nil {
+		t.Error("should raise error, but not raised")
+	}
+	if err := app.WriteDataFile("data.txt", nil); err == nil {
+		t.Error("should raise error, but not raised")
+	}
+}
+
+func TestSyncDir(t *testing.T) {
+	if err := NewApp("test").syncDir(t.TempDir()); err != nil {
+		t.Error(err)
+	}
+	if err := NewApp("test").syncDir(path(t.TempDir(), "none")); err == nil {
+		t.Error("should raise error, but not raised")
+	}
+}
+
+func TestSyncDirOnWindows(t *testing.T) {
+	if err := NewApp("test", WithGOOS("windows")).syncDir(path(t.TempDir(), "none")); err != nil {
+		t.Errorf("should be no-op on windows, but got %v", err)
+	}
+}
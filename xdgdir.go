@@ -1,10 +1,11 @@
 package xdgdir
 
 import (
-	"errors"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strconv"
+	"strings"
 )
 
 // ConfigDir returns base directory path of config files that does not contain subdirectory for app.
@@ -13,7 +14,7 @@ import (
 // 2. IF HOME envvar is defiend, returns $HOME/.config
 // 3. IF USERPROFILE envvar is defiend, returns $USERPROFILE/.config (for Windows)
 func ConfigDir() (string, error) {
-	return buildHome("XDG_CONFIG_HOME", ".config")
+	return buildHome(os.Getenv, runtime.GOOS, "XDG_CONFIG_HOME", ".config")
 }
 
 // DataDir returns base directory path of data files that does not contain subdirectory for app.
@@ -22,7 +23,7 @@ func ConfigDir() (string, error) {
 // 2. IF HOME envvar is defiend, returns $HOME/.local/share
 // 3. IF USERPROFILE envvar is defiend, returns $USERPROFILE/.local/share (for Windows)
 func DataDir() (string, error) {
-	return buildHome("XDG_DATA_HOME", ".local", "share")
+	return buildHome(os.Getenv, runtime.GOOS, "XDG_DATA_HOME", ".local", "share")
 }
 
 // CacheDir returns base directory path of cache files that does not contain subdirectory for app.
@@ -31,7 +32,16 @@ func DataDir() (string, error) {
 // 2. IF HOME envvar is defiend, returns $HOME/.cache
 // 3. IF USERPROFILE envvar is defiend, returns $USERPROFILE/.cache (for Windows)
 func CacheDir() (string, error) {
-	return buildHome("XDG_CACHE_HOME", ".cache")
+	return buildHome(os.Getenv, runtime.GOOS, "XDG_CACHE_HOME", ".cache")
+}
+
+// StateDir returns base directory path of state files that does not contain subdirectory for app.
+//
+// 1. If XDG_STATE_HOME envvar is defined, returns it.
+// 2. IF HOME envvar is defined, returns $HOME/.local/state
+// 3. IF USERPROFILE envvar is defined, returns $USERPROFILE/.local/state (for Windows)
+func StateDir() (string, error) {
+	return buildHome(os.Getenv, runtime.GOOS, "XDG_STATE_HOME", ".local", "state")
 }
 
 // RuntimeDir returns base directory path of runtime files that does not contain subdirectory for app.
@@ -39,37 +49,65 @@ func CacheDir() (string, error) {
 // 1. If XDG_RUNTIME_DIR envvar is defiend, returns it.
 // 2. Returns temporary directory path.
 func RuntimeDir() string {
-	xDir := os.Getenv("XDG_RUNTIME_DIR")
+	return buildRuntime(os.Getenv, strconv.Itoa(os.Getuid()))
+}
+
+// BinDir returns user's directory of executables.
+//
+// 1. IF HOME envvar is defined, returns $HOME/.local/bin
+// 2. IF USERPROFILE envvar is defined, returns $USERPROFILE/.local/bin (for Windows)
+func BinDir() (string, error) {
+	return binDir(os.Getenv, runtime.GOOS)
+}
+
+func binDir(getenv func(string) string, goos string) (string, error) {
+	home := homeDir(getenv, goos)
+	if home == "" {
+		return "", ErrNoHome
+	}
+	return filepath.Join(home, ".local", "bin"), nil
+}
+
+func buildRuntime(getenv func(string) string, uid string) string {
+	xDir := strings.TrimSpace(getenv("XDG_RUNTIME_DIR"))
 	if xDir != "" {
 		return xDir
 	}
 
-	return filepath.Join(os.TempDir(), strconv.Itoa(os.Getuid()))
+	return filepath.Join(os.TempDir(), uid)
 }
 
-func buildHome(env string, paths ...string) (string, error) {
-	xdgHome := os.Getenv(env)
+func buildHome(getenv func(string) string, goos string, env string, paths ...string) (string, error) {
+	xdgHome := strings.TrimSpace(getenv(env))
 	if xdgHome != "" {
 		return xdgHome, nil
 	}
 
-	home := homeDir()
+	home := homeDir(getenv, goos)
 	if home == "" {
-		return "", errors.New("home directory not found")
+		return "", ErrNoHome
 	}
 
-	elem := make([]string, len(paths)+1)
-	elem[0] = home
-	for i, p := range paths {
-		elem[i+1] = p
+	var buf [4]string
+	return filepath.Join(append(append(buf[:0], home), paths...)...), nil
+}
+
+// homeDir returns home directory from HOME or USERPROFILE envvar, or from $home on Plan 9.
+// Unlike os.UserHomeDir, it does not default to "/" on js and others, so that ErrNoHome is returned
+// instead of paths under root directory.
+func homeDir(getenv func(string) string, goos string) string {
+	for _, env := range homeEnvs(goos) {
+		if home := getenv(env); home != "" {
+			return home
+		}
 	}
-	return filepath.Join(elem...), nil
+	return ""
 }
 
-func homeDir() string {
-	home := os.Getenv("HOME")
-	if home != "" {
-		return home
+// homeEnvs returns envvars that homeDir consults in order.
+func homeEnvs(goos string) []string {
+	if goos == "plan9" {
+		return []string{"HOME", "home"}
 	}
-	return os.Getenv("USERPROFILE")
+	return []string{"HOME", "USERPROFILE"}
 }
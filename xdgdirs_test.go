@@ -1,6 +1,7 @@
 package xdgdir
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -15,7 +16,10 @@ func TestConfigDir(t *testing.T) {
 		err         bool
 	}{
 		{"x", "y", "z", "x", false},
+		{" x\t", "y", "z", "x", false},
 		{"", "y", "z", path("y", ".config"), false},
+		{" ", "y", "z", path("y", ".config"), false},
+		{"\t", "y", "z", path("y", ".config"), false},
 		{"", "", "z", path("z", ".config"), false},
 		{"", "", "", "", true},
 	}
@@ -108,6 +112,40 @@ func TestCacheDir(t *testing.T) {
 	}
 }
 
+func TestStateDir(t *testing.T) {
+	table := []struct {
+		xdgHome     string
+		home        string
+		userProfile string
+		expected    string
+		err         bool
+	}{
+		{"x", "y", "z", "x", false},
+		{"", "y", "z", path("y", ".local", "state"), false},
+		{"", "", "z", path("z", ".local", "state"), false},
+		{"", "", "", "", true},
+	}
+
+	for _, tbl := range table {
+		os.Setenv("XDG_STATE_HOME", tbl.xdgHome)
+		os.Setenv("HOME", tbl.home)
+		os.Setenv("USERPROFILE", tbl.userProfile)
+		dir, err := StateDir()
+		if tbl.err {
+			if err == nil {
+				t.Error("should raise error, but not raised")
+			}
+		} else {
+			if err != nil {
+				t.Error(err)
+			}
+			if dir != tbl.expected {
+				t.Errorf("expected %s, but got %s", tbl.expected, dir)
+			}
+		}
+	}
+}
+
 func TestRuntimeDir(t *testing.T) {
 	if RuntimeDir() == "" {
 		t.Error("runtime dir should be not empty")
@@ -117,8 +155,38 @@ func TestRuntimeDir(t *testing.T) {
 	if dir := RuntimeDir(); dir != "x" {
 		t.Errorf("expected x, but got %s", dir)
 	}
+
+	os.Setenv("XDG_RUNTIME_DIR", " x ")
+	if dir := RuntimeDir(); dir != "x" {
+		t.Errorf("expected x, but got %s", dir)
+	}
 }
 
 func path(elm ...string) string {
 	return filepath.Join(elm...)
 }
+
+func TestHomeDirForGOOS(t *testing.T) {
+	table := []struct {
+		goos     string
+		env      []string
+		expected string
+		err      error
+	}{
+		{"linux", []string{"HOME=/home/u"}, path("/home", "u", ".config", "test"), nil},
+		{"windows", []string{"USERPROFILE=" + path("C:", "Users", "u")}, path("C:", "Users", "u", ".config", "test"), nil},
+		{"plan9", []string{"home=/usr/glenda"}, path("/usr", "glenda", ".config", "test"), nil},
+		{"linux", []string{"home=/usr/glenda"}, "", ErrNoHome},
+		{"js", nil, "", ErrNoHome},
+		{"wasip1", []string{"HOME=/home/u"}, path("/home", "u", ".config", "test"), nil},
+	}
+	for _, tbl := range table {
+		dir, err := NewApp("test", WithEnviron(tbl.env), WithGOOS(tbl.goos)).ConfigDir()
+		if !errors.Is(err, tbl.err) {
+			t.Errorf("expected %v for %s, but got %v", tbl.err, tbl.goos, err)
+		}
+		if dir != tbl.expected {
+			t.Errorf("expected %s for %s, but got %s", tbl.expected, tbl.goos, dir)
+		}
+	}
+}